@@ -0,0 +1,79 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// aliasStore is a store resolving server address aliases.
+type aliasStore struct {
+	inner   Store
+	resolve func(serverAddress string) string
+}
+
+// NewAliasStore returns a store that resolves server addresses through the
+// given alias map, from alias to canonical server address, before passing
+// Get(), Put() and Delete() calls to the inner store. This allows a registry
+// exposed under multiple hostnames, for example regional endpoints, to share
+// a single entry in the inner store. Server addresses not in the map are
+// passed as is.
+//
+// The alias map is copied, so later changes to it do not affect the store.
+func NewAliasStore(inner Store, aliases map[string]string) Store {
+	aliasMap := make(map[string]string, len(aliases))
+	for alias, serverAddress := range aliases {
+		aliasMap[alias] = serverAddress
+	}
+	return NewAliasResolverStore(inner, func(serverAddress string) string {
+		if canonical, ok := aliasMap[serverAddress]; ok {
+			return canonical
+		}
+		return serverAddress
+	})
+}
+
+// NewAliasResolverStore returns a store that resolves server addresses with
+// the given function, from alias to canonical server address, before passing
+// Get(), Put() and Delete() calls to the inner store. Unlike [NewAliasStore],
+// aliases do not need to be known in advance, so resolve may, for example,
+// follow DNS CNAME records for organizations whose registry hostnames are
+// aliases of the host credentials are stored under. resolve should return
+// the server address as is if it is not an alias, and is called on every
+// operation, so any costly resolution should be cached by resolve itself.
+func NewAliasResolverStore(inner Store, resolve func(serverAddress string) string) Store {
+	return &aliasStore{
+		inner:   inner,
+		resolve: resolve,
+	}
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (as *aliasStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	return as.inner.Get(ctx, as.resolve(serverAddress))
+}
+
+// Put saves credentials into the store for the given server address.
+func (as *aliasStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	return as.inner.Put(ctx, as.resolve(serverAddress), cred)
+}
+
+// Delete removes credentials from the store for the given server address.
+func (as *aliasStore) Delete(ctx context.Context, serverAddress string) error {
+	return as.inner.Delete(ctx, as.resolve(serverAddress))
+}
@@ -0,0 +1,144 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestAliasStore(t *testing.T) {
+	ctx := context.Background()
+	inner := NewMemoryStore()
+	aliases := map[string]string{
+		"eu.registry.example.com": "registry.example.com",
+		"us.registry.example.com": "registry.example.com",
+	}
+	as := NewAliasStore(inner, aliases)
+	// changing the map should not affect the store
+	aliases["other.example.com"] = "registry.example.com"
+
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := as.Put(ctx, "eu.registry.example.com", cred); err != nil {
+		t.Fatal("aliasStore.Put() error =", err)
+	}
+
+	// the credential should be saved under the canonical server address
+	got, err := inner.Get(ctx, "registry.example.com")
+	if err != nil {
+		t.Fatal("MemoryStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("MemoryStore.Get() = %v, want %v", got, cred)
+	}
+
+	tests := []struct {
+		serverAddress string
+		want          auth.Credential
+	}{
+		{"registry.example.com", cred},
+		{"eu.registry.example.com", cred},
+		{"us.registry.example.com", cred},
+		{"other.example.com", auth.EmptyCredential},
+	}
+	for _, tt := range tests {
+		got, err := as.Get(ctx, tt.serverAddress)
+		if err != nil {
+			t.Fatal("aliasStore.Get() error =", err)
+		}
+		if got != tt.want {
+			t.Errorf("aliasStore.Get(%s) = %v, want %v", tt.serverAddress, got, tt.want)
+		}
+	}
+
+	// deleting through an alias should delete the shared entry
+	if err := as.Delete(ctx, "us.registry.example.com"); err != nil {
+		t.Fatal("aliasStore.Delete() error =", err)
+	}
+	if got, err = as.Get(ctx, "eu.registry.example.com"); err != nil {
+		t.Fatal("aliasStore.Get() error =", err)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("aliasStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+}
+
+func TestAliasStore_badStore(t *testing.T) {
+	ctx := context.Background()
+	as := NewAliasStore(&badStore{}, nil)
+	serverAddress := "registry.example.com"
+	if _, err := as.Get(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("aliasStore.Get() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := as.Put(ctx, serverAddress, auth.EmptyCredential); !errors.Is(err, errBadStore) {
+		t.Errorf("aliasStore.Put() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := as.Delete(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("aliasStore.Delete() error = %v, wantErr %v", err, errBadStore)
+	}
+}
+
+func TestAliasResolverStore(t *testing.T) {
+	ctx := context.Background()
+	inner := NewMemoryStore()
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := inner.Put(ctx, "registry-prod.cloud.example.com", cred); err != nil {
+		t.Fatal("MemoryStore.Put() error =", err)
+	}
+	var resolved []string
+	as := NewAliasResolverStore(inner, func(serverAddress string) string {
+		resolved = append(resolved, serverAddress)
+		if serverAddress == "registry.corp" {
+			return "registry-prod.cloud.example.com"
+		}
+		return serverAddress
+	})
+
+	got, err := as.Get(ctx, "registry.corp")
+	if err != nil {
+		t.Fatal("aliasStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("aliasStore.Get() = %v, want %v", got, cred)
+	}
+	if got, err = as.Get(ctx, "other.corp"); err != nil {
+		t.Fatal("aliasStore.Get() error =", err)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("aliasStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+	if err := as.Delete(ctx, "registry.corp"); err != nil {
+		t.Fatal("aliasStore.Delete() error =", err)
+	}
+	if got, err = inner.Get(ctx, "registry-prod.cloud.example.com"); err != nil {
+		t.Fatal("MemoryStore.Get() error =", err)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("MemoryStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+	if len(resolved) != 3 {
+		t.Errorf("resolve called %d times, want 3", len(resolved))
+	}
+}
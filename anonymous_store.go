@@ -0,0 +1,66 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// anonymousStore is a store skipping the lookup of anonymous registries.
+type anonymousStore struct {
+	inner     Store
+	anonymous map[string]struct{}
+}
+
+// NewAnonymousRegistriesStore returns a store whose Get() returns
+// auth.EmptyCredential right away for the given registries, without calling
+// the inner store, which saves invoking credential helpers, and possibly
+// prompting, for registries that are always accessed anonymously. The
+// registries are mapped by [ServerAddressFromRegistry], so "docker.io" stands
+// for Docker Hub.
+//
+// Get() of other server addresses, Put() and Delete() are passed to the inner
+// store as is.
+func NewAnonymousRegistriesStore(inner Store, registries []string) Store {
+	anonymous := make(map[string]struct{}, len(registries))
+	for _, registry := range registries {
+		anonymous[ServerAddressFromRegistry(registry)] = struct{}{}
+	}
+	return &anonymousStore{
+		inner:     inner,
+		anonymous: anonymous,
+	}
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (as *anonymousStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	if _, ok := as.anonymous[serverAddress]; ok {
+		return auth.EmptyCredential, nil
+	}
+	return as.inner.Get(ctx, serverAddress)
+}
+
+// Put saves credentials into the store for the given server address.
+func (as *anonymousStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	return as.inner.Put(ctx, serverAddress, cred)
+}
+
+// Delete removes credentials from the store for the given server address.
+func (as *anonymousStore) Delete(ctx context.Context, serverAddress string) error {
+	return as.inner.Delete(ctx, serverAddress)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestAnonymousRegistriesStore(t *testing.T) {
+	ctx := context.Background()
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	inner := &countingStore{Store: NewMemoryStore()}
+	for _, serverAddress := range []string{"public.example.com", "private.example.com", "https://index.docker.io/v1/"} {
+		if err := inner.Put(ctx, serverAddress, cred); err != nil {
+			t.Fatal("MemoryStore.Put() error =", err)
+		}
+	}
+	as := NewAnonymousRegistriesStore(inner, []string{"public.example.com", "docker.io"})
+
+	// anonymous registries should not reach the inner store
+	for _, serverAddress := range []string{"public.example.com", "https://index.docker.io/v1/"} {
+		got, err := as.Get(ctx, serverAddress)
+		if err != nil {
+			t.Fatal("anonymousStore.Get() error =", err)
+		}
+		if got != auth.EmptyCredential {
+			t.Errorf("anonymousStore.Get(%s) = %v, want %v", serverAddress, got, auth.EmptyCredential)
+		}
+	}
+	if inner.gets != 0 {
+		t.Errorf("inner store Get() called %d times, want 0", inner.gets)
+	}
+
+	// other registries should be looked up in the inner store
+	got, err := as.Get(ctx, "private.example.com")
+	if err != nil {
+		t.Fatal("anonymousStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("anonymousStore.Get() = %v, want %v", got, cred)
+	}
+	if inner.gets != 1 {
+		t.Errorf("inner store Get() called %d times, want 1", inner.gets)
+	}
+}
+
+func TestAnonymousRegistriesStore_badStore(t *testing.T) {
+	ctx := context.Background()
+	as := NewAnonymousRegistriesStore(&badStore{}, []string{"public.example.com"})
+	if _, err := as.Get(ctx, "public.example.com"); err != nil {
+		t.Error("anonymousStore.Get() error =", err)
+	}
+	serverAddress := "registry.example.com"
+	if _, err := as.Get(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("anonymousStore.Get() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := as.Put(ctx, serverAddress, auth.EmptyCredential); !errors.Is(err, errBadStore) {
+		t.Errorf("anonymousStore.Put() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := as.Delete(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("anonymousStore.Delete() error = %v, wantErr %v", err, errBadStore)
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"reflect"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func Test_authConfig_credential(t *testing.T) {
+	tests := []struct {
+		name    string
+		ac      authConfig
+		want    auth.Credential
+		wantErr bool
+	}{
+		{
+			name: "auth field",
+			ac: authConfig{
+				Auth: "dXNlcm5hbWU6cGFzc3dvcmQ=",
+			},
+			want: auth.Credential{
+				Username: "username",
+				Password: "password",
+			},
+		},
+		{
+			name: "auth field overrides legacy fields",
+			ac: authConfig{
+				Auth:     "dXNlcm5hbWU6cGFzc3dvcmQ=",
+				Username: "foo",
+				Password: "bar",
+			},
+			want: auth.Credential{
+				Username: "username",
+				Password: "password",
+			},
+		},
+		{
+			name: "legacy fields",
+			ac: authConfig{
+				Username: "foo",
+				Password: "bar",
+			},
+			want: auth.Credential{
+				Username: "foo",
+				Password: "bar",
+			},
+		},
+		{
+			name: "tokens",
+			ac: authConfig{
+				IdentityToken: "identity_token",
+				RegistryToken: "registry_token",
+			},
+			want: auth.Credential{
+				RefreshToken: "identity_token",
+				AccessToken:  "registry_token",
+			},
+		},
+		{
+			name: "bad base64",
+			ac: authConfig{
+				Auth: "whatever",
+			},
+			wantErr: true,
+		},
+		{
+			name: "no colon",
+			ac: authConfig{
+				Auth: "dXNlcm5hbWVwYXNzd29yZA==",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.ac.credential()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("authConfig.credential() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("authConfig.credential() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newAuthConfig(t *testing.T) {
+	cred := auth.Credential{
+		Username:     "username",
+		Password:     "password",
+		RefreshToken: "identity_token",
+		AccessToken:  "registry_token",
+	}
+	want := authConfig{
+		Auth:          "dXNlcm5hbWU6cGFzc3dvcmQ=",
+		IdentityToken: "identity_token",
+		RegistryToken: "registry_token",
+	}
+	if got := newAuthConfig(cred); !reflect.DeepEqual(got, want) {
+		t.Errorf("newAuthConfig() = %v, want %v", got, want)
+	}
+	if got := newAuthConfig(auth.EmptyCredential); !reflect.DeepEqual(got, authConfig{}) {
+		t.Errorf("newAuthConfig() = %v, want %v", got, authConfig{})
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// writeThroughCacheStore is a store caching the credentials of an inner store.
+type writeThroughCacheStore struct {
+	inner Store
+	ttl   time.Duration
+	// now returns the current time. It is replaceable for testing purpose.
+	now func() time.Time
+
+	lock    sync.Mutex
+	entries map[string]cacheEntry
+	// generations counts the writes of each server address, so that a Get()
+	// racing with a Put() or Delete() does not cache the credential it read
+	// before the write.
+	generations map[string]uint64
+	// writers counts the in-flight writes of each server address.
+	writers map[string]int
+}
+
+// cacheEntry is an entry of writeThroughCacheStore.
+type cacheEntry struct {
+	cred    auth.Credential
+	expires time.Time
+}
+
+// NewWriteThroughCacheStore returns a store that caches the credentials of the
+// inner store for the given ttl. If ttl is not positive, cached credentials
+// never expire.
+//   - Get() returns the cached credential of the server address if it has not
+//     expired. Otherwise, it gets the credential from the inner store and
+//     caches the result, including an empty credential.
+//   - Put() saves the credential into the inner store and, on success, caches
+//     it. Thus a Get() following a Put() returns the new credential without
+//     calling the inner store.
+//   - Delete() deletes the credential from the inner store and, on success,
+//     caches an empty credential.
+//
+// If Put() or Delete() fails, the cached credential of the server address is
+// invalidated since the state of the inner store is unknown. A Get() running
+// concurrently with a Put() or Delete() of the same server address does not
+// cache the credential it got from the inner store, as it may predate the
+// write. Likewise, concurrent writes of the same server address are not
+// cached, as the order in which they reach the inner store is unknown.
+func NewWriteThroughCacheStore(inner Store, ttl time.Duration) Store {
+	return &writeThroughCacheStore{
+		inner:       inner,
+		ttl:         ttl,
+		now:         time.Now,
+		entries:     make(map[string]cacheEntry),
+		generations: make(map[string]uint64),
+		writers:     make(map[string]int),
+	}
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (cs *writeThroughCacheStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	cs.lock.Lock()
+	entry, ok := cs.entries[serverAddress]
+	generation := cs.generations[serverAddress]
+	cs.lock.Unlock()
+	if ok && (cs.ttl <= 0 || cs.now().Before(entry.expires)) {
+		return entry.cred, nil
+	}
+
+	cred, err := cs.inner.Get(ctx, serverAddress)
+	if err != nil {
+		return auth.EmptyCredential, err
+	}
+	cs.fill(serverAddress, generation, cred)
+	return cred, nil
+}
+
+// Put saves credentials into the store for the given server address.
+func (cs *writeThroughCacheStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	generation := cs.beginWrite(serverAddress)
+	err := cs.inner.Put(ctx, serverAddress, cred)
+	cs.endWrite(serverAddress, generation, cred, err == nil)
+	return err
+}
+
+// Delete removes credentials from the store for the given server address.
+func (cs *writeThroughCacheStore) Delete(ctx context.Context, serverAddress string) error {
+	generation := cs.beginWrite(serverAddress)
+	err := cs.inner.Delete(ctx, serverAddress)
+	cs.endWrite(serverAddress, generation, auth.EmptyCredential, err == nil)
+	return err
+}
+
+// beginWrite invalidates the cached credential of serverAddress before a
+// write to the inner store, and returns the generation of the write.
+func (cs *writeThroughCacheStore) beginWrite(serverAddress string) uint64 {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+	cs.generations[serverAddress]++
+	cs.writers[serverAddress]++
+	delete(cs.entries, serverAddress)
+	return cs.generations[serverAddress]
+}
+
+// endWrite caches cred written for serverAddress if the write of the given
+// generation succeeded and no other write of serverAddress has run
+// concurrently, since the order in which concurrent writes reach the inner
+// store is unknown. Otherwise, the cached credential is invalidated.
+func (cs *writeThroughCacheStore) endWrite(serverAddress string, generation uint64, cred auth.Credential, ok bool) {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+	cs.writers[serverAddress]--
+	writers := cs.writers[serverAddress]
+	if writers == 0 {
+		delete(cs.writers, serverAddress)
+	}
+	ok = ok && writers == 0 && cs.generations[serverAddress] == generation
+	// bump the generation so that Gets started during the write do not cache
+	// what they read
+	cs.generations[serverAddress]++
+	if !ok {
+		delete(cs.entries, serverAddress)
+		return
+	}
+	cs.entries[serverAddress] = cacheEntry{
+		cred:    cred,
+		expires: cs.now().Add(cs.ttl),
+	}
+}
+
+// fill caches cred read for serverAddress, unless serverAddress has been
+// written since the given generation or is being written.
+func (cs *writeThroughCacheStore) fill(serverAddress string, generation uint64, cred auth.Credential) {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+	if cs.generations[serverAddress] != generation || cs.writers[serverAddress] != 0 {
+		return
+	}
+	cs.entries[serverAddress] = cacheEntry{
+		cred:    cred,
+		expires: cs.now().Add(cs.ttl),
+	}
+}
@@ -0,0 +1,347 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// countingStore is a store counting the calls to the inner store, used for
+// testing purpose.
+type countingStore struct {
+	Store
+	gets int
+	err  error
+}
+
+func (cs *countingStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	cs.gets++
+	return cs.Store.Get(ctx, serverAddress)
+}
+
+func (cs *countingStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	if cs.err != nil {
+		return cs.err
+	}
+	return cs.Store.Put(ctx, serverAddress, cred)
+}
+
+func (cs *countingStore) Delete(ctx context.Context, serverAddress string) error {
+	if cs.err != nil {
+		return cs.err
+	}
+	return cs.Store.Delete(ctx, serverAddress)
+}
+
+func TestWriteThroughCacheStore_Get_ttl(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingStore{Store: NewMemoryStore()}
+	serverAddress := "registry.example.com"
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := inner.Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("MemoryStore.Put() error =", err)
+	}
+
+	now := time.Now()
+	cs := NewWriteThroughCacheStore(inner, time.Minute).(*writeThroughCacheStore)
+	cs.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		got, err := cs.Get(ctx, serverAddress)
+		if err != nil {
+			t.Fatal("writeThroughCacheStore.Get() error =", err)
+		}
+		if got != cred {
+			t.Errorf("writeThroughCacheStore.Get() = %v, want %v", got, cred)
+		}
+	}
+	if inner.gets != 1 {
+		t.Errorf("inner store Get() called %d times, want 1", inner.gets)
+	}
+
+	// the cached credential should expire after ttl
+	now = now.Add(time.Minute)
+	if _, err := cs.Get(ctx, serverAddress); err != nil {
+		t.Fatal("writeThroughCacheStore.Get() error =", err)
+	}
+	if inner.gets != 2 {
+		t.Errorf("inner store Get() called %d times, want 2", inner.gets)
+	}
+}
+
+func TestWriteThroughCacheStore_Get_noExpiry(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingStore{Store: NewMemoryStore()}
+	now := time.Now()
+	cs := NewWriteThroughCacheStore(inner, 0).(*writeThroughCacheStore)
+	cs.now = func() time.Time { return now }
+
+	if _, err := cs.Get(ctx, "registry.example.com"); err != nil {
+		t.Fatal("writeThroughCacheStore.Get() error =", err)
+	}
+	now = now.Add(24 * time.Hour)
+	if _, err := cs.Get(ctx, "registry.example.com"); err != nil {
+		t.Fatal("writeThroughCacheStore.Get() error =", err)
+	}
+	if inner.gets != 1 {
+		t.Errorf("inner store Get() called %d times, want 1", inner.gets)
+	}
+}
+
+func TestWriteThroughCacheStore_readAfterWrite(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingStore{Store: NewMemoryStore()}
+	cs := NewWriteThroughCacheStore(inner, time.Hour)
+	serverAddress := "registry.example.com"
+
+	// populate the cache with an empty credential
+	if _, err := cs.Get(ctx, serverAddress); err != nil {
+		t.Fatal("writeThroughCacheStore.Get() error =", err)
+	}
+
+	// Put should update the cache
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := cs.Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("writeThroughCacheStore.Put() error =", err)
+	}
+	got, err := cs.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("writeThroughCacheStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("writeThroughCacheStore.Get() = %v, want %v", got, cred)
+	}
+
+	// Delete should update the cache
+	if err := cs.Delete(ctx, serverAddress); err != nil {
+		t.Fatal("writeThroughCacheStore.Delete() error =", err)
+	}
+	got, err = cs.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("writeThroughCacheStore.Get() error =", err)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("writeThroughCacheStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+
+	// reads after writes should be served from the cache
+	if inner.gets != 1 {
+		t.Errorf("inner store Get() called %d times, want 1", inner.gets)
+	}
+}
+
+// staleReadStore is a store whose Get() reads the credential, then blocks
+// until released before returning it, used for testing purpose.
+type staleReadStore struct {
+	Store
+	read    chan struct{}
+	release chan struct{}
+}
+
+func (ss *staleReadStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	cred, err := ss.Store.Get(ctx, serverAddress)
+	close(ss.read)
+	<-ss.release
+	return cred, err
+}
+
+func TestWriteThroughCacheStore_Get_concurrentPut(t *testing.T) {
+	ctx := context.Background()
+	serverAddress := "registry.example.com"
+	oldCred := auth.Credential{
+		Username: "username",
+		Password: "old_password",
+	}
+	inner := &staleReadStore{
+		Store:   NewMemoryStore(),
+		read:    make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	if err := inner.Put(ctx, serverAddress, oldCred); err != nil {
+		t.Fatal("MemoryStore.Put() error =", err)
+	}
+	cs := NewWriteThroughCacheStore(inner, 0)
+
+	// a Get() reading the old credential races with a Put()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := cs.Get(ctx, serverAddress); err != nil {
+			t.Error("writeThroughCacheStore.Get() error =", err)
+		}
+	}()
+	<-inner.read
+	newCred := auth.Credential{
+		Username: "username",
+		Password: "new_password",
+	}
+	if err := cs.Put(ctx, serverAddress, newCred); err != nil {
+		t.Fatal("writeThroughCacheStore.Put() error =", err)
+	}
+	close(inner.release)
+	<-done
+
+	// the old credential should not overwrite the cached new credential
+	got, err := cs.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("writeThroughCacheStore.Get() error =", err)
+	}
+	if got != newCred {
+		t.Errorf("writeThroughCacheStore.Get() = %v, want %v", got, newCred)
+	}
+}
+
+// pausingPutStore is a store whose Put() of the given credential blocks until
+// released, either before or after saving it, used for testing purpose.
+type pausingPutStore struct {
+	Store
+	cred        auth.Credential
+	pauseBefore bool
+	pausing     chan struct{}
+	release     chan struct{}
+}
+
+func (ps *pausingPutStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	if cred != ps.cred {
+		return ps.Store.Put(ctx, serverAddress, cred)
+	}
+	if ps.pauseBefore {
+		close(ps.pausing)
+		<-ps.release
+	}
+	err := ps.Store.Put(ctx, serverAddress, cred)
+	if !ps.pauseBefore {
+		close(ps.pausing)
+		<-ps.release
+	}
+	return err
+}
+
+func TestWriteThroughCacheStore_Put_concurrentPut(t *testing.T) {
+	ctx := context.Background()
+	serverAddress := "registry.example.com"
+	credA := auth.Credential{
+		Username: "username",
+		Password: "password_a",
+	}
+	credB := auth.Credential{
+		Username: "username",
+		Password: "password_b",
+	}
+	tests := []struct {
+		name        string
+		pauseBefore bool
+		want        auth.Credential
+	}{
+		{
+			name:        "first put reaches the inner store first",
+			pauseBefore: false,
+			want:        credB,
+		},
+		{
+			name:        "first put reaches the inner store last",
+			pauseBefore: true,
+			want:        credA,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &pausingPutStore{
+				Store:       NewMemoryStore(),
+				cred:        credA,
+				pauseBefore: tt.pauseBefore,
+				pausing:     make(chan struct{}),
+				release:     make(chan struct{}),
+			}
+			cs := NewWriteThroughCacheStore(inner, 0)
+
+			// credA is put while credB is put concurrently
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				if err := cs.Put(ctx, serverAddress, credA); err != nil {
+					t.Error("writeThroughCacheStore.Put() error =", err)
+				}
+			}()
+			<-inner.pausing
+			if err := cs.Put(ctx, serverAddress, credB); err != nil {
+				t.Fatal("writeThroughCacheStore.Put() error =", err)
+			}
+			close(inner.release)
+			<-done
+
+			// the cache should agree with the inner store
+			got, err := inner.Get(ctx, serverAddress)
+			if err != nil {
+				t.Fatal("MemoryStore.Get() error =", err)
+			}
+			if got != tt.want {
+				t.Fatalf("MemoryStore.Get() = %v, want %v", got, tt.want)
+			}
+			if got, err = cs.Get(ctx, serverAddress); err != nil {
+				t.Fatal("writeThroughCacheStore.Get() error =", err)
+			}
+			if got != tt.want {
+				t.Errorf("writeThroughCacheStore.Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteThroughCacheStore_writeError(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingStore{Store: NewMemoryStore()}
+	cs := NewWriteThroughCacheStore(inner, time.Hour)
+	serverAddress := "registry.example.com"
+	if _, err := cs.Get(ctx, serverAddress); err != nil {
+		t.Fatal("writeThroughCacheStore.Get() error =", err)
+	}
+
+	// failed writes should invalidate the cache
+	inner.err = errBadStore
+	if err := cs.Put(ctx, serverAddress, auth.Credential{Username: "username"}); !errors.Is(err, errBadStore) {
+		t.Errorf("writeThroughCacheStore.Put() error = %v, wantErr %v", err, errBadStore)
+	}
+	if _, err := cs.Get(ctx, serverAddress); err != nil {
+		t.Fatal("writeThroughCacheStore.Get() error =", err)
+	}
+	if err := cs.Delete(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("writeThroughCacheStore.Delete() error = %v, wantErr %v", err, errBadStore)
+	}
+	if _, err := cs.Get(ctx, serverAddress); err != nil {
+		t.Fatal("writeThroughCacheStore.Get() error =", err)
+	}
+	if inner.gets != 3 {
+		t.Errorf("inner store Get() called %d times, want 3", inner.gets)
+	}
+
+	// failed reads should not be cached
+	cs = NewWriteThroughCacheStore(&badStore{}, time.Hour)
+	if _, err := cs.Get(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("writeThroughCacheStore.Get() error = %v, wantErr %v", err, errBadStore)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"strings"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// canonicalKeyStore is a store saving credentials under canonical keys.
+type canonicalKeyStore struct {
+	inner Store
+}
+
+// NewCanonicalKeyStore returns a store that saves credentials under the
+// canonical form of the server address, as returned by
+// [CanonicalServerAddress], to prevent accumulating duplicate keys for the
+// same registry.
+//   - Put() saves the credential under the canonical key.
+//   - Get() looks up the canonical key first, then the server address as
+//     given, so entries saved before canonicalization was enabled are still
+//     found.
+//   - Delete() removes the credentials under both the canonical key and the
+//     server address as given. Only the keys having credentials are deleted,
+//     so deleting succeeds when either of them is missing.
+//
+// As hostnames are case-insensitive, wrapping a store created by NewFileStore
+// or NewStore makes a lookup of "registry.example.com" find the credential put
+// for "Registry.Example.com", and vice versa. The port is preserved.
+// Likewise, "registry.example.com/" and "registry.example.com" share the same
+// credential, regardless of the form used to put it.
+func NewCanonicalKeyStore(inner Store) Store {
+	return &canonicalKeyStore{inner: inner}
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (cs *canonicalKeyStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	key := CanonicalServerAddress(serverAddress)
+	cred, err := cs.inner.Get(ctx, key)
+	if err != nil || cred != auth.EmptyCredential || key == serverAddress {
+		return cred, err
+	}
+	return cs.inner.Get(ctx, serverAddress)
+}
+
+// Put saves credentials into the store for the given server address.
+func (cs *canonicalKeyStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	return cs.inner.Put(ctx, CanonicalServerAddress(serverAddress), cred)
+}
+
+// Delete removes credentials from the store for the given server address.
+func (cs *canonicalKeyStore) Delete(ctx context.Context, serverAddress string) error {
+	keys := []string{CanonicalServerAddress(serverAddress)}
+	if keys[0] != serverAddress {
+		keys = append(keys, serverAddress)
+	}
+	// only erase the existing keys, as stores such as native helpers fail to
+	// erase missing ones, and attempt all of them so that a failure does not
+	// leave the other key behind
+	var errs []error
+	for _, key := range keys {
+		cred, err := cs.inner.Get(ctx, key)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if cred == auth.EmptyCredential {
+			continue
+		}
+		if err := cs.inner.Delete(ctx, key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return joinErrors(errs...)
+}
+
+// CanonicalServerAddress returns the canonical form of serverAddress used as
+// a key by [NewCanonicalKeyStore]: the host is lowercased, the scheme, path
+// and trailing slash are stripped, and Docker Hub is mapped to
+// "https://index.docker.io/v1/", as done by [DockerCredentialKey].
+func CanonicalServerAddress(serverAddress string) string {
+	return DockerCredentialKey(strings.ToLower(serverAddress))
+}
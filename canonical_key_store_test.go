@@ -0,0 +1,248 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestCanonicalServerAddress(t *testing.T) {
+	tests := []struct {
+		serverAddress string
+		want          string
+	}{
+		{"registry.example.com", "registry.example.com"},
+		{"Registry.Example.COM:5000", "registry.example.com:5000"},
+		{"https://registry.example.com/", "registry.example.com"},
+		{"http://registry.example.com/v2/", "registry.example.com"},
+		{"docker.io", "https://index.docker.io/v1/"},
+		{"Index.Docker.io", "https://index.docker.io/v1/"},
+		{"https://index.docker.io/v1/", "https://index.docker.io/v1/"},
+	}
+	for _, tt := range tests {
+		if got := CanonicalServerAddress(tt.serverAddress); got != tt.want {
+			t.Errorf("CanonicalServerAddress(%s) = %v, want %v", tt.serverAddress, got, tt.want)
+		}
+	}
+}
+
+func TestCanonicalKeyStore(t *testing.T) {
+	ctx := context.Background()
+	inner := NewMemoryStore()
+	cs := NewCanonicalKeyStore(inner)
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+
+	// test put under a non-canonical key
+	if err := cs.Put(ctx, "https://Registry.Example.com/", cred); err != nil {
+		t.Fatal("canonicalKeyStore.Put() error =", err)
+	}
+	got, err := inner.Get(ctx, "registry.example.com")
+	if err != nil {
+		t.Fatal("MemoryStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("MemoryStore.Get() = %v, want %v", got, cred)
+	}
+	if got, err = cs.Get(ctx, "REGISTRY.example.com"); err != nil {
+		t.Fatal("canonicalKeyStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("canonicalKeyStore.Get() = %v, want %v", got, cred)
+	}
+
+	// test get legacy entry saved under a non-canonical key
+	legacyServer := "Legacy.example.com"
+	legacyCred := auth.Credential{
+		RefreshToken: "identity_token",
+	}
+	if err := inner.Put(ctx, legacyServer, legacyCred); err != nil {
+		t.Fatal("MemoryStore.Put() error =", err)
+	}
+	if got, err = cs.Get(ctx, legacyServer); err != nil {
+		t.Fatal("canonicalKeyStore.Get() error =", err)
+	}
+	if got != legacyCred {
+		t.Errorf("canonicalKeyStore.Get() = %v, want %v", got, legacyCred)
+	}
+
+	// test delete removes both keys
+	if err := inner.Put(ctx, "legacy.example.com", cred); err != nil {
+		t.Fatal("MemoryStore.Put() error =", err)
+	}
+	if err := cs.Delete(ctx, legacyServer); err != nil {
+		t.Fatal("canonicalKeyStore.Delete() error =", err)
+	}
+	for _, serverAddress := range []string{legacyServer, "legacy.example.com"} {
+		if got, err = inner.Get(ctx, serverAddress); err != nil {
+			t.Fatal("MemoryStore.Get() error =", err)
+		}
+		if got != auth.EmptyCredential {
+			t.Errorf("MemoryStore.Get(%s) = %v, want %v", serverAddress, got, auth.EmptyCredential)
+		}
+	}
+}
+
+// strictDeleteStore is a store failing to delete missing credentials, like
+// native helpers do, used for testing purpose.
+type strictDeleteStore struct {
+	Store
+}
+
+func (ss *strictDeleteStore) Delete(ctx context.Context, serverAddress string) error {
+	cred, err := ss.Store.Get(ctx, serverAddress)
+	if err != nil {
+		return err
+	}
+	if cred == auth.EmptyCredential {
+		return errors.New(errCredentialsNotFoundMessage)
+	}
+	return ss.Store.Delete(ctx, serverAddress)
+}
+
+func TestCanonicalKeyStore_Delete_singleKey(t *testing.T) {
+	ctx := context.Background()
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	serverAddress := "https://Registry.example.com/"
+	for _, key := range []string{"registry.example.com", serverAddress} {
+		t.Run(key, func(t *testing.T) {
+			inner := &strictDeleteStore{Store: NewMemoryStore()}
+			if err := inner.Put(ctx, key, cred); err != nil {
+				t.Fatal("MemoryStore.Put() error =", err)
+			}
+			cs := NewCanonicalKeyStore(inner)
+			if err := cs.Delete(ctx, serverAddress); err != nil {
+				t.Fatal("canonicalKeyStore.Delete() error =", err)
+			}
+			got, err := inner.Get(ctx, key)
+			if err != nil {
+				t.Fatal("MemoryStore.Get() error =", err)
+			}
+			if got != auth.EmptyCredential {
+				t.Errorf("MemoryStore.Get(%s) = %v, want %v", key, got, auth.EmptyCredential)
+			}
+		})
+	}
+}
+
+func TestCanonicalKeyStore_fileStore_mixedCaseHost(t *testing.T) {
+	ctx := context.Background()
+	fs, err := NewFileStore(filepath.Join(t.TempDir(), "config.json"))
+	if err != nil {
+		t.Fatal("NewFileStore() error =", err)
+	}
+	cs := NewCanonicalKeyStore(fs)
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	tests := []struct {
+		putAddress string
+		getAddress string
+	}{
+		{"Registry.Example.com", "registry.example.com"},
+		{"registry2.example.com", "REGISTRY2.Example.COM"},
+		{"Registry3.Example.com:5000", "registry3.example.com:5000"},
+	}
+	for _, tt := range tests {
+		if err := cs.Put(ctx, tt.putAddress, cred); err != nil {
+			t.Fatal("canonicalKeyStore.Put() error =", err)
+		}
+		got, err := cs.Get(ctx, tt.getAddress)
+		if err != nil {
+			t.Fatal("canonicalKeyStore.Get() error =", err)
+		}
+		if got != cred {
+			t.Errorf("canonicalKeyStore.Get(%s) = %v, want %v", tt.getAddress, got, cred)
+		}
+	}
+	// the port should be preserved
+	got, err := cs.Get(ctx, "registry3.example.com")
+	if err != nil {
+		t.Fatal("canonicalKeyStore.Get() error =", err)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("canonicalKeyStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+}
+
+func TestCanonicalKeyStore_fileStore_trailingSlash(t *testing.T) {
+	ctx := context.Background()
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	tests := []struct {
+		name       string
+		putAddress string
+		getAddress string
+	}{
+		{
+			name:       "stored with slash, lookup without",
+			putAddress: "registry.example.com/",
+			getAddress: "registry.example.com",
+		},
+		{
+			name:       "stored without slash, lookup with",
+			putAddress: "registry.example.com",
+			getAddress: "registry.example.com/",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs, err := NewFileStore(filepath.Join(t.TempDir(), "config.json"))
+			if err != nil {
+				t.Fatal("NewFileStore() error =", err)
+			}
+			cs := NewCanonicalKeyStore(fs)
+			if err := cs.Put(ctx, tt.putAddress, cred); err != nil {
+				t.Fatal("canonicalKeyStore.Put() error =", err)
+			}
+			got, err := cs.Get(ctx, tt.getAddress)
+			if err != nil {
+				t.Fatal("canonicalKeyStore.Get() error =", err)
+			}
+			if got != cred {
+				t.Errorf("canonicalKeyStore.Get() = %v, want %v", got, cred)
+			}
+		})
+	}
+}
+
+func TestCanonicalKeyStore_badStore(t *testing.T) {
+	ctx := context.Background()
+	cs := NewCanonicalKeyStore(&badStore{})
+	serverAddress := "Registry.example.com"
+	if _, err := cs.Get(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("canonicalKeyStore.Get() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := cs.Put(ctx, serverAddress, auth.EmptyCredential); !errors.Is(err, errBadStore) {
+		t.Errorf("canonicalKeyStore.Put() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := cs.Delete(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("canonicalKeyStore.Delete() error = %v, wantErr %v", err, errBadStore)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// concurrencyLimitedStore is a store bounding concurrent operations.
+type concurrencyLimitedStore struct {
+	inner Store
+	sem   chan struct{}
+}
+
+// NewConcurrencyLimitedStore returns a store that runs at most limit Get(),
+// Put() and Delete() calls on the inner store at a time. Further calls wait
+// for a slot, or return the context error if the context is done first.
+// A limit of 1 serializes the calls.
+//
+// Wrapping a store created by NewNativeStore, or by NewStore when the config
+// uses credential helpers, bounds the number of helper processes spawned by
+// massively parallel operations. If limit is not positive, the inner store is
+// returned as is.
+func NewConcurrencyLimitedStore(inner Store, limit int) Store {
+	if limit <= 0 {
+		return inner
+	}
+	return &concurrencyLimitedStore{
+		inner: inner,
+		sem:   make(chan struct{}, limit),
+	}
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (cs *concurrencyLimitedStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	if err := cs.acquire(ctx); err != nil {
+		return auth.EmptyCredential, err
+	}
+	defer cs.release()
+	return cs.inner.Get(ctx, serverAddress)
+}
+
+// Put saves credentials into the store for the given server address.
+func (cs *concurrencyLimitedStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	if err := cs.acquire(ctx); err != nil {
+		return err
+	}
+	defer cs.release()
+	return cs.inner.Put(ctx, serverAddress, cred)
+}
+
+// Delete removes credentials from the store for the given server address.
+func (cs *concurrencyLimitedStore) Delete(ctx context.Context, serverAddress string) error {
+	if err := cs.acquire(ctx); err != nil {
+		return err
+	}
+	defer cs.release()
+	return cs.inner.Delete(ctx, serverAddress)
+}
+
+// acquire waits for a free slot or for ctx to be done.
+func (cs *concurrencyLimitedStore) acquire(ctx context.Context) error {
+	select {
+	case cs.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees the slot taken by acquire.
+func (cs *concurrencyLimitedStore) release() {
+	<-cs.sem
+}
@@ -0,0 +1,120 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// peakStore is a store recording the peak number of concurrent Get() calls,
+// used for testing purpose.
+type peakStore struct {
+	Store
+	active int32
+	peak   int32
+}
+
+func (ps *peakStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	active := atomic.AddInt32(&ps.active, 1)
+	defer atomic.AddInt32(&ps.active, -1)
+	for {
+		peak := atomic.LoadInt32(&ps.peak)
+		if active <= peak || atomic.CompareAndSwapInt32(&ps.peak, peak, active) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	return ps.Store.Get(ctx, serverAddress)
+}
+
+func TestConcurrencyLimitedStore_Get_concurrent(t *testing.T) {
+	ctx := context.Background()
+	inner := &peakStore{Store: NewMemoryStore()}
+	const limit = 2
+	cs := NewConcurrencyLimitedStore(inner, limit)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := cs.Get(ctx, "registry.example.com"); err != nil {
+				t.Error("concurrencyLimitedStore.Get() error =", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := atomic.LoadInt32(&inner.peak); got > limit {
+		t.Errorf("peak concurrent Get() calls = %d, want at most %d", got, limit)
+	}
+}
+
+func TestConcurrencyLimitedStore_contextDone(t *testing.T) {
+	inner := &blockingStore{
+		Store:   NewMemoryStore(),
+		release: make(chan struct{}),
+	}
+	defer close(inner.release)
+	cs := NewConcurrencyLimitedStore(inner, 1)
+	serverAddress := "registry.example.com"
+
+	// occupy the only slot
+	go cs.Get(context.Background(), serverAddress)
+	for atomic.LoadInt32(&inner.gets) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := cs.Get(ctx, serverAddress); !errors.Is(err, context.Canceled) {
+		t.Errorf("concurrencyLimitedStore.Get() error = %v, wantErr %v", err, context.Canceled)
+	}
+	if err := cs.Put(ctx, serverAddress, auth.EmptyCredential); !errors.Is(err, context.Canceled) {
+		t.Errorf("concurrencyLimitedStore.Put() error = %v, wantErr %v", err, context.Canceled)
+	}
+	if err := cs.Delete(ctx, serverAddress); !errors.Is(err, context.Canceled) {
+		t.Errorf("concurrencyLimitedStore.Delete() error = %v, wantErr %v", err, context.Canceled)
+	}
+}
+
+func TestConcurrencyLimitedStore_noLimit(t *testing.T) {
+	inner := NewMemoryStore()
+	if got := NewConcurrencyLimitedStore(inner, 0); got != inner {
+		t.Errorf("NewConcurrencyLimitedStore() = %v, want the inner store", got)
+	}
+}
+
+func TestConcurrencyLimitedStore_badStore(t *testing.T) {
+	ctx := context.Background()
+	cs := NewConcurrencyLimitedStore(&badStore{}, 1)
+	serverAddress := "registry.example.com"
+	if _, err := cs.Get(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("concurrencyLimitedStore.Get() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := cs.Put(ctx, serverAddress, auth.EmptyCredential); !errors.Is(err, errBadStore) {
+		t.Errorf("concurrencyLimitedStore.Put() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := cs.Delete(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("concurrencyLimitedStore.Delete() error = %v, wantErr %v", err, errBadStore)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import "sort"
+
+// Inconsistency describes an entry of the "auths" field of a docker config
+// file whose "auth" field disagrees with its legacy "username" and
+// "password" fields.
+type Inconsistency struct {
+	// ServerAddress is the key of the entry.
+	ServerAddress string
+	// Reason describes the disagreement, without revealing any secret.
+	Reason string
+}
+
+// CheckConfigConsistency reads the docker config file at configPath and
+// reports, sorted by server address, the entries containing both the "auth"
+// field and the legacy "username" or "password" fields where the decoded
+// "auth" field disagrees with the legacy fields. As the "auth" field wins
+// when reading credentials, the legacy fields of such entries are ignored,
+// which is usually the result of the entry being edited by different tools.
+//
+// A non-existing config file has no inconsistencies.
+func CheckConfigConsistency(configPath string) ([]Inconsistency, error) {
+	cfg, err := loadDockerConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	serverAddresses := make([]string, 0, len(cfg.AuthConfigs))
+	for serverAddress := range cfg.AuthConfigs {
+		serverAddresses = append(serverAddresses, serverAddress)
+	}
+	sort.Strings(serverAddresses)
+	var inconsistencies []Inconsistency
+	for _, serverAddress := range serverAddresses {
+		ac := cfg.AuthConfigs[serverAddress]
+		if ac.Auth == "" || (ac.Username == "" && ac.Password == "") {
+			continue
+		}
+		var reason string
+		username, password, err := decodeAuth(ac.Auth)
+		switch {
+		case err != nil:
+			reason = "auth field cannot be decoded"
+		case username != ac.Username && password != ac.Password:
+			reason = "auth field disagrees with the username and password fields"
+		case username != ac.Username:
+			reason = "auth field disagrees with the username field"
+		case password != ac.Password:
+			reason = "auth field disagrees with the password field"
+		default:
+			continue
+		}
+		inconsistencies = append(inconsistencies, Inconsistency{
+			ServerAddress: serverAddress,
+			Reason:        reason,
+		})
+	}
+	return inconsistencies, nil
+}
+
+// ConflictPair describes two keys of the "auths" field of a docker config file
+// that have the same canonical server address, so that which of them is used
+// depends on the spelling of the registry being looked up.
+type ConflictPair struct {
+	// Canonical is the canonical server address of both keys, as returned by
+	// [CanonicalServerAddress].
+	Canonical string
+	// First and Second are the conflicting keys, First sorting before Second.
+	First, Second string
+}
+
+// ConfigConflicts reads the docker config file at configPath and reports,
+// sorted, the pairs of keys of the "auths" field having the same canonical
+// server address, such as "docker.io" and "https://index.docker.io/v1/". Such
+// entries shadow each other and often cause intermittent authentication
+// failures, so tools may warn about them after loading a config file.
+//
+// A non-existing config file has no conflicts.
+func ConfigConflicts(configPath string) ([]ConflictPair, error) {
+	cfg, err := loadDockerConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string][]string)
+	for serverAddress := range cfg.AuthConfigs {
+		canonical := CanonicalServerAddress(serverAddress)
+		groups[canonical] = append(groups[canonical], serverAddress)
+	}
+	var conflicts []ConflictPair
+	for canonical, keys := range groups {
+		sort.Strings(keys)
+		for i := range keys {
+			for _, other := range keys[i+1:] {
+				conflicts = append(conflicts, ConflictPair{
+					Canonical: canonical,
+					First:     keys[i],
+					Second:    other,
+				})
+			}
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].First != conflicts[j].First {
+			return conflicts[i].First < conflicts[j].First
+		}
+		return conflicts[i].Second < conflicts[j].Second
+	})
+	return conflicts, nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/oras-project/oras-credentials-go/internal/config/configtest"
+)
+
+func TestCheckConfigConsistency(t *testing.T) {
+	// prepare test content
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.json")
+	cfg := configtest.Config{
+		AuthConfigs: map[string]configtest.AuthConfig{
+			"consistent.example.com": {
+				Auth:     "dXNlcm5hbWU6cGFzc3dvcmQ=", // username:password
+				Username: "username",
+				Password: "password",
+			},
+			"auth-only.example.com": {
+				Auth: "dXNlcm5hbWU6cGFzc3dvcmQ=",
+			},
+			"legacy-only.example.com": {
+				Username: "username",
+				Password: "password",
+			},
+			"username.example.com": {
+				Auth:     "dXNlcm5hbWU6cGFzc3dvcmQ=",
+				Username: "other",
+				Password: "password",
+			},
+			"password.example.com": {
+				Auth:     "dXNlcm5hbWU6cGFzc3dvcmQ=",
+				Username: "username",
+			},
+			"both.example.com": {
+				Auth:     "dXNlcm5hbWU6cGFzc3dvcmQ=",
+				Username: "other",
+				Password: "other",
+			},
+			"bad-auth.example.com": {
+				Auth:     "whatever",
+				Username: "username",
+				Password: "password",
+			},
+		},
+	}
+	jsonStr, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("failed to marshal config: %v", err)
+	}
+	if err := os.WriteFile(configPath, jsonStr, 0666); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	got, err := CheckConfigConsistency(configPath)
+	if err != nil {
+		t.Fatal("CheckConfigConsistency() error =", err)
+	}
+	want := []Inconsistency{
+		{ServerAddress: "bad-auth.example.com", Reason: "auth field cannot be decoded"},
+		{ServerAddress: "both.example.com", Reason: "auth field disagrees with the username and password fields"},
+		{ServerAddress: "password.example.com", Reason: "auth field disagrees with the password field"},
+		{ServerAddress: "username.example.com", Reason: "auth field disagrees with the username field"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CheckConfigConsistency() = %v, want %v", got, want)
+	}
+}
+
+func TestCheckConfigConsistency_notExistFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "whatever.json")
+	got, err := CheckConfigConsistency(configPath)
+	if err != nil {
+		t.Fatal("CheckConfigConsistency() error =", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("CheckConfigConsistency() = %v, want empty", got)
+	}
+}
+
+func TestCheckConfigConsistency_badFormat(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "bad_config.json")
+	if err := os.WriteFile(configPath, []byte("whatever"), 0666); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	if _, err := CheckConfigConsistency(configPath); err == nil {
+		t.Error("CheckConfigConsistency() error = nil, want error")
+	}
+}
+
+func TestConfigConflicts(t *testing.T) {
+	// prepare test content
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.json")
+	cfg := configtest.Config{
+		AuthConfigs: map[string]configtest.AuthConfig{
+			"docker.io":                    {},
+			"index.docker.io":              {},
+			"https://index.docker.io/v1/":  {},
+			"registry.example.com":         {},
+			"https://Registry.example.com": {},
+			"other.example.com":            {},
+		},
+	}
+	jsonStr, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("failed to marshal config: %v", err)
+	}
+	if err := os.WriteFile(configPath, jsonStr, 0666); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	got, err := ConfigConflicts(configPath)
+	if err != nil {
+		t.Fatal("ConfigConflicts() error =", err)
+	}
+	dockerHub := "https://index.docker.io/v1/"
+	want := []ConflictPair{
+		{Canonical: dockerHub, First: "docker.io", Second: "https://index.docker.io/v1/"},
+		{Canonical: dockerHub, First: "docker.io", Second: "index.docker.io"},
+		{Canonical: "registry.example.com", First: "https://Registry.example.com", Second: "registry.example.com"},
+		{Canonical: dockerHub, First: "https://index.docker.io/v1/", Second: "index.docker.io"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ConfigConflicts() = %v, want %v", got, want)
+	}
+}
+
+func TestConfigConflicts_notExistFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "whatever.json")
+	got, err := ConfigConflicts(configPath)
+	if err != nil {
+		t.Fatal("ConfigConflicts() error =", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("ConfigConflicts() = %v, want empty", got)
+	}
+}
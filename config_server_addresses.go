@@ -0,0 +1,52 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import "sort"
+
+// ConfigServerAddresses reads the docker config file at configPath and
+// returns the sorted union of the keys of its "auths" and "credHelpers"
+// fields, for displaying the configured registries. Unlike the Lister
+// returned by NewConfigLister, no credential helper is invoked and no secret
+// is decoded, so the server addresses stored only in the "credsStore" helper
+// are not included.
+//
+// A non-existing config file has no server addresses.
+func ConfigServerAddresses(configPath string) ([]string, error) {
+	cfg, err := loadDockerConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.serverAddresses(), nil
+}
+
+// serverAddresses returns the sorted union of the keys of the "auths" and
+// "credHelpers" fields.
+func (cfg dockerConfig) serverAddresses() []string {
+	set := make(map[string]struct{}, len(cfg.AuthConfigs)+len(cfg.CredentialHelpers))
+	for serverAddress := range cfg.AuthConfigs {
+		set[serverAddress] = struct{}{}
+	}
+	for serverAddress := range cfg.CredentialHelpers {
+		set[serverAddress] = struct{}{}
+	}
+	serverAddresses := make([]string, 0, len(set))
+	for serverAddress := range set {
+		serverAddresses = append(serverAddresses, serverAddress)
+	}
+	sort.Strings(serverAddresses)
+	return serverAddresses
+}
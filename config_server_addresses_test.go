@@ -0,0 +1,82 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/oras-project/oras-credentials-go/internal/config/configtest"
+)
+
+func TestConfigServerAddresses(t *testing.T) {
+	// prepare test content
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.json")
+	cfg := configtest.Config{
+		AuthConfigs: map[string]configtest.AuthConfig{
+			"registry2.example.com": {
+				Auth: "dXNlcm5hbWU6cGFzc3dvcmQ=",
+			},
+			"both.example.com": {},
+		},
+		CredentialsStore: "nonexistent",
+		CredentialHelpers: map[string]string{
+			"registry1.example.com": "nonexistent",
+			"both.example.com":      "nonexistent",
+		},
+	}
+	jsonStr, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("failed to marshal config: %v", err)
+	}
+	if err := os.WriteFile(configPath, jsonStr, 0666); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	// the helpers do not exist, so they must not be invoked
+	got, err := ConfigServerAddresses(configPath)
+	if err != nil {
+		t.Fatal("ConfigServerAddresses() error =", err)
+	}
+	want := []string{"both.example.com", "registry1.example.com", "registry2.example.com"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ConfigServerAddresses() = %v, want %v", got, want)
+	}
+}
+
+func TestConfigServerAddresses_noConfigFile(t *testing.T) {
+	got, err := ConfigServerAddresses(filepath.Join(t.TempDir(), "config.json"))
+	if err != nil {
+		t.Fatal("ConfigServerAddresses() error =", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("ConfigServerAddresses() = %v, want empty", got)
+	}
+}
+
+func TestConfigServerAddresses_badConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte("{"), 0666); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	if _, err := ConfigServerAddresses(configPath); err == nil {
+		t.Error("ConfigServerAddresses() error = nil, want error")
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import "sort"
+
+// ConfigSnapshot is a view of the credential configuration of a docker config
+// file, without any secret.
+type ConfigSnapshot struct {
+	// CredentialsStore is the value of the "credsStore" field.
+	CredentialsStore string
+	// CredentialHelpers is the value of the "credHelpers" field, mapping
+	// server addresses to credential helper suffixes.
+	CredentialHelpers map[string]string
+	// AuthServerAddresses are the sorted keys of the "auths" field.
+	AuthServerAddresses []string
+}
+
+// SnapshotConfig reads the docker config file at configPath and returns a
+// snapshot of its credential configuration, for debugging or for rendering
+// the configuration in user interfaces. The snapshot is read from the file
+// and shares no state with any store, so it is not affected by later changes
+// to the file, and modifying it does not affect any store.
+//
+// A non-existing config file results in an empty snapshot.
+func SnapshotConfig(configPath string) (ConfigSnapshot, error) {
+	cfg, err := loadDockerConfig(configPath)
+	if err != nil {
+		return ConfigSnapshot{}, err
+	}
+	snapshot := ConfigSnapshot{
+		CredentialsStore:    cfg.CredentialsStore,
+		CredentialHelpers:   cfg.CredentialHelpers,
+		AuthServerAddresses: make([]string, 0, len(cfg.AuthConfigs)),
+	}
+	for serverAddress := range cfg.AuthConfigs {
+		snapshot.AuthServerAddresses = append(snapshot.AuthServerAddresses, serverAddress)
+	}
+	sort.Strings(snapshot.AuthServerAddresses)
+	return snapshot, nil
+}
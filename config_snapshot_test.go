@@ -0,0 +1,85 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/oras-project/oras-credentials-go/internal/config/configtest"
+)
+
+func TestSnapshotConfig(t *testing.T) {
+	// prepare test content
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.json")
+	cfg := configtest.Config{
+		AuthConfigs: map[string]configtest.AuthConfig{
+			"registry2.example.com": {
+				Auth: "dXNlcm5hbWU6cGFzc3dvcmQ=",
+			},
+			"registry1.example.com": {
+				IdentityToken: "identity_token",
+			},
+		},
+		CredentialsStore: "pass",
+		CredentialHelpers: map[string]string{
+			"registry3.example.com": "ecr-login",
+		},
+	}
+	jsonStr, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("failed to marshal config: %v", err)
+	}
+	if err := os.WriteFile(configPath, jsonStr, 0666); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	got, err := SnapshotConfig(configPath)
+	if err != nil {
+		t.Fatal("SnapshotConfig() error =", err)
+	}
+	want := ConfigSnapshot{
+		CredentialsStore: "pass",
+		CredentialHelpers: map[string]string{
+			"registry3.example.com": "ecr-login",
+		},
+		AuthServerAddresses: []string{"registry1.example.com", "registry2.example.com"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SnapshotConfig() = %v, want %v", got, want)
+	}
+	for _, secret := range []string{"dXNlcm5hbWU6cGFzc3dvcmQ=", "identity_token"} {
+		if strings.Contains(fmt.Sprint(got), secret) {
+			t.Errorf("SnapshotConfig() = %v, contains secret %q", got, secret)
+		}
+	}
+}
+
+func TestSnapshotConfig_noConfigFile(t *testing.T) {
+	got, err := SnapshotConfig(filepath.Join(t.TempDir(), "config.json"))
+	if err != nil {
+		t.Fatal("SnapshotConfig() error =", err)
+	}
+	if got.CredentialsStore != "" || len(got.CredentialHelpers) != 0 || len(got.AuthServerAddresses) != 0 {
+		t.Errorf("SnapshotConfig() = %v, want empty", got)
+	}
+}
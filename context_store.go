@@ -0,0 +1,75 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// contextStore is a store decorating the context of every operation.
+type contextStore struct {
+	inner    Store
+	decorate func(context.Context) (context.Context, context.CancelFunc)
+}
+
+// NewContextStore returns a store that applies decorate to the context of
+// every Get(), Put() and Delete() call before passing the call to the inner
+// store. It centralizes the context setup of credential operations, such as
+// adding trace hooks or a default deadline.
+//
+// The cancel function returned by decorate is called once the inner call
+// returns, so a context created by context.WithTimeout can be returned as is.
+// Decorators not deriving a cancelable context may return a nil cancel
+// function, which is treated as a no-op.
+func NewContextStore(inner Store, decorate func(context.Context) (context.Context, context.CancelFunc)) Store {
+	return &contextStore{
+		inner:    inner,
+		decorate: decorate,
+	}
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (cs *contextStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	ctx, cancel := cs.decorateContext(ctx)
+	defer cancel()
+	return cs.inner.Get(ctx, serverAddress)
+}
+
+// Put saves credentials into the store for the given server address.
+func (cs *contextStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	ctx, cancel := cs.decorateContext(ctx)
+	defer cancel()
+	return cs.inner.Put(ctx, serverAddress, cred)
+}
+
+// Delete removes credentials from the store for the given server address.
+func (cs *contextStore) Delete(ctx context.Context, serverAddress string) error {
+	ctx, cancel := cs.decorateContext(ctx)
+	defer cancel()
+	return cs.inner.Delete(ctx, serverAddress)
+}
+
+// decorateContext applies decorate to ctx, replacing a nil cancel function
+// with a no-op one.
+func (cs *contextStore) decorateContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := cs.decorate(ctx)
+	if cancel == nil {
+		cancel = func() {}
+	}
+	return ctx, cancel
+}
@@ -0,0 +1,144 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+type testContextKey struct{}
+
+// contextRecordingStore is a store recording the context value of every
+// operation, used for testing purpose.
+type contextRecordingStore struct {
+	Store
+	values []interface{}
+}
+
+func (cs *contextRecordingStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	cs.values = append(cs.values, ctx.Value(testContextKey{}))
+	return cs.Store.Get(ctx, serverAddress)
+}
+
+func (cs *contextRecordingStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	cs.values = append(cs.values, ctx.Value(testContextKey{}))
+	return cs.Store.Put(ctx, serverAddress, cred)
+}
+
+func (cs *contextRecordingStore) Delete(ctx context.Context, serverAddress string) error {
+	cs.values = append(cs.values, ctx.Value(testContextKey{}))
+	return cs.Store.Delete(ctx, serverAddress)
+}
+
+func TestContextStore(t *testing.T) {
+	ctx := context.Background()
+	inner := &contextRecordingStore{Store: NewMemoryStore()}
+	var cancelled int
+	cs := NewContextStore(inner, func(ctx context.Context) (context.Context, context.CancelFunc) {
+		return context.WithValue(ctx, testContextKey{}, "correlation-id"), func() {
+			cancelled++
+		}
+	})
+	serverAddress := "registry.example.com"
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+
+	if err := cs.Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("contextStore.Put() error =", err)
+	}
+	got, err := cs.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("contextStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("contextStore.Get() = %v, want %v", got, cred)
+	}
+	if err := cs.Delete(ctx, serverAddress); err != nil {
+		t.Fatal("contextStore.Delete() error =", err)
+	}
+
+	if len(inner.values) != 3 {
+		t.Fatalf("inner store called %d times, want 3", len(inner.values))
+	}
+	for i, v := range inner.values {
+		if v != "correlation-id" {
+			t.Errorf("context value of call %d = %v, want %v", i, v, "correlation-id")
+		}
+	}
+	if cancelled != 3 {
+		t.Errorf("cancel called %d times, want 3", cancelled)
+	}
+}
+
+func TestContextStore_timeout(t *testing.T) {
+	var decorated []context.Context
+	cs := NewContextStore(NewMemoryStore(), func(ctx context.Context) (context.Context, context.CancelFunc) {
+		ctx, cancel := context.WithTimeout(ctx, time.Hour)
+		decorated = append(decorated, ctx)
+		return ctx, cancel
+	})
+	if _, err := cs.Get(context.Background(), "registry.example.com"); err != nil {
+		t.Fatal("contextStore.Get() error =", err)
+	}
+	// the decorated context should be released once the call returns
+	if len(decorated) != 1 {
+		t.Fatalf("decorate called %d times, want 1", len(decorated))
+	}
+	if err := decorated[0].Err(); !errors.Is(err, context.Canceled) {
+		t.Errorf("decorated context error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestContextStore_nilCancel(t *testing.T) {
+	ctx := context.Background()
+	cs := NewContextStore(NewMemoryStore(), func(ctx context.Context) (context.Context, context.CancelFunc) {
+		return ctx, nil
+	})
+	serverAddress := "registry.example.com"
+	if err := cs.Put(ctx, serverAddress, auth.Credential{Username: "username"}); err != nil {
+		t.Fatal("contextStore.Put() error =", err)
+	}
+	if _, err := cs.Get(ctx, serverAddress); err != nil {
+		t.Fatal("contextStore.Get() error =", err)
+	}
+	if err := cs.Delete(ctx, serverAddress); err != nil {
+		t.Fatal("contextStore.Delete() error =", err)
+	}
+}
+
+func TestContextStore_badStore(t *testing.T) {
+	ctx := context.Background()
+	cs := NewContextStore(&badStore{}, func(ctx context.Context) (context.Context, context.CancelFunc) {
+		return context.WithCancel(ctx)
+	})
+	serverAddress := "registry.example.com"
+	if _, err := cs.Get(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("contextStore.Get() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := cs.Put(ctx, serverAddress, auth.EmptyCredential); !errors.Is(err, errBadStore) {
+		t.Errorf("contextStore.Put() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := cs.Delete(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("contextStore.Delete() error = %v, wantErr %v", err, errBadStore)
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"fmt"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// Kind describes which authentication scheme a credential is meant for.
+type Kind int
+
+const (
+	// KindEmpty indicates an empty credential.
+	KindEmpty Kind = iota
+	// KindBasic indicates a credential with only a username and/or a
+	// password, used for basic authentication.
+	KindBasic
+	// KindRefreshToken indicates a credential with only a refresh token, also
+	// known as an identity token.
+	KindRefreshToken
+	// KindAccessToken indicates a credential with only an access token, also
+	// known as a registry token.
+	KindAccessToken
+	// KindMixed indicates a credential combining fields of more than one of
+	// the kinds above.
+	KindMixed
+)
+
+// String returns the string representation of the Kind.
+func (k Kind) String() string {
+	switch k {
+	case KindEmpty:
+		return "empty"
+	case KindBasic:
+		return "basic"
+	case KindRefreshToken:
+		return "refresh token"
+	case KindAccessToken:
+		return "access token"
+	case KindMixed:
+		return "mixed"
+	default:
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+}
+
+// CredentialKind returns the kind of the given credential based on which of
+// its fields are set.
+func CredentialKind(cred auth.Credential) Kind {
+	kind := KindEmpty
+	set := func(k Kind) {
+		if kind == KindEmpty {
+			kind = k
+		} else {
+			kind = KindMixed
+		}
+	}
+	if cred.Username != "" || cred.Password != "" {
+		set(KindBasic)
+	}
+	if cred.RefreshToken != "" {
+		set(KindRefreshToken)
+	}
+	if cred.AccessToken != "" {
+		set(KindAccessToken)
+	}
+	return kind
+}
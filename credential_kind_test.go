@@ -0,0 +1,108 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestCredentialKind(t *testing.T) {
+	tests := []struct {
+		name string
+		cred auth.Credential
+		want Kind
+	}{
+		{
+			name: "empty",
+			cred: auth.EmptyCredential,
+			want: KindEmpty,
+		},
+		{
+			name: "username and password",
+			cred: auth.Credential{
+				Username: "username",
+				Password: "password",
+			},
+			want: KindBasic,
+		},
+		{
+			name: "username only",
+			cred: auth.Credential{
+				Username: "username",
+			},
+			want: KindBasic,
+		},
+		{
+			name: "refresh token",
+			cred: auth.Credential{
+				RefreshToken: "identity_token",
+			},
+			want: KindRefreshToken,
+		},
+		{
+			name: "access token",
+			cred: auth.Credential{
+				AccessToken: "registry_token",
+			},
+			want: KindAccessToken,
+		},
+		{
+			name: "basic and refresh token",
+			cred: auth.Credential{
+				Username:     "username",
+				Password:     "password",
+				RefreshToken: "identity_token",
+			},
+			want: KindMixed,
+		},
+		{
+			name: "refresh token and access token",
+			cred: auth.Credential{
+				RefreshToken: "identity_token",
+				AccessToken:  "registry_token",
+			},
+			want: KindMixed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CredentialKind(tt.cred); got != tt.want {
+				t.Errorf("CredentialKind() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKind_String(t *testing.T) {
+	tests := []struct {
+		kind Kind
+		want string
+	}{
+		{KindEmpty, "empty"},
+		{KindBasic, "basic"},
+		{KindRefreshToken, "refresh token"},
+		{KindAccessToken, "access token"},
+		{KindMixed, "mixed"},
+		{Kind(42), "Kind(42)"},
+	}
+	for _, tt := range tests {
+		if got := tt.kind.String(); got != tt.want {
+			t.Errorf("Kind.String() = %v, want %v", got, tt.want)
+		}
+	}
+}
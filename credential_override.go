@@ -0,0 +1,53 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// credentialOverrideKey is the context key for credential overrides.
+type credentialOverrideKey struct{}
+
+// WithCredentialOverride returns a Context carrying the given credential for
+// the given server address. The Credential() function returned by
+// [Credential] prefers the credential carried by the context over the one in
+// the store, without mutating the store. The server address is mapped by
+// [ServerAddressFromRegistry], so an override for "docker.io" applies to
+// Docker Hub, as looked up by the Credential() function.
+//
+// If the Context already carries overrides, the new override is added in
+// addition to them, replacing any previous override for the same server
+// address.
+func WithCredentialOverride(ctx context.Context, serverAddress string, cred auth.Credential) context.Context {
+	old, _ := ctx.Value(credentialOverrideKey{}).(map[string]auth.Credential)
+	overrides := make(map[string]auth.Credential, len(old)+1)
+	for k, v := range old {
+		overrides[k] = v
+	}
+	overrides[ServerAddressFromRegistry(serverAddress)] = cred
+	return context.WithValue(ctx, credentialOverrideKey{}, overrides)
+}
+
+// credentialOverride returns the credential override for the given server
+// address carried by the context, if any.
+func credentialOverride(ctx context.Context, serverAddress string) (auth.Credential, bool) {
+	overrides, _ := ctx.Value(credentialOverrideKey{}).(map[string]auth.Credential)
+	cred, ok := overrides[serverAddress]
+	return cred, ok
+}
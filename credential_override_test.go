@@ -0,0 +1,114 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestCredential_withCredentialOverride(t *testing.T) {
+	// create a test store
+	s := &testStore{}
+	s.storage = map[string]auth.Credential{
+		"localhost:2333":              {Username: "test_user", Password: "test_word"},
+		"localhost:6666":              {Username: "other_user", Password: "other_word"},
+		"https://index.docker.io/v1/": {Username: "user", Password: "word"},
+	}
+	overrideCred := auth.Credential{Username: "override_user", Password: "override_word"}
+	dockerCred := auth.Credential{RefreshToken: "identity_token"}
+	ctx := WithCredentialOverride(context.Background(), "localhost:2333", auth.Credential{Username: "replaced"})
+	ctx = WithCredentialOverride(ctx, "localhost:2333", overrideCred)
+	ctx = WithCredentialOverride(ctx, "https://index.docker.io/v1/", dockerCred)
+	credFunc := Credential(s)
+
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		registry       string
+		wantCredential auth.Credential
+	}{
+		{
+			name:           "override takes precedence over the store",
+			ctx:            ctx,
+			registry:       "localhost:2333",
+			wantCredential: overrideCred,
+		},
+		{
+			name:           "override for registry-1.docker.io",
+			ctx:            ctx,
+			registry:       "registry-1.docker.io",
+			wantCredential: dockerCred,
+		},
+		{
+			name:           "no override for the registry",
+			ctx:            ctx,
+			registry:       "localhost:6666",
+			wantCredential: auth.Credential{Username: "other_user", Password: "other_word"},
+		},
+		{
+			name:           "no override in the context",
+			ctx:            context.Background(),
+			registry:       "localhost:2333",
+			wantCredential: auth.Credential{Username: "test_user", Password: "test_word"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := credFunc(tt.ctx, tt.registry)
+			if err != nil {
+				t.Fatalf("could not get credential: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.wantCredential) {
+				t.Errorf("Credential() = %v, want %v", got, tt.wantCredential)
+			}
+		})
+	}
+
+	// the store should not be mutated
+	if got, want := s.storage["localhost:2333"], (auth.Credential{Username: "test_user", Password: "test_word"}); got != want {
+		t.Errorf("stored credential = %v, want %v", got, want)
+	}
+}
+
+func TestWithCredentialOverride_doesNotAffectParent(t *testing.T) {
+	parent := WithCredentialOverride(context.Background(), "localhost:2333", auth.Credential{Username: "parent"})
+	_ = WithCredentialOverride(parent, "localhost:6666", auth.Credential{Username: "child"})
+	if _, ok := credentialOverride(parent, "localhost:6666"); ok {
+		t.Error("child override leaked into the parent context")
+	}
+}
+
+func TestCredential_withCredentialOverride_dockerIO(t *testing.T) {
+	s := &testStore{}
+	s.storage = map[string]auth.Credential{
+		"https://index.docker.io/v1/": {Username: "user", Password: "word"},
+	}
+	overrideCred := auth.Credential{Username: "override_user", Password: "override_word"}
+	ctx := WithCredentialOverride(context.Background(), "docker.io", overrideCred)
+
+	// auth.Client looks up Docker Hub as registry-1.docker.io
+	got, err := Credential(s)(ctx, "registry-1.docker.io")
+	if err != nil {
+		t.Fatalf("could not get credential: %v", err)
+	}
+	if got != overrideCred {
+		t.Errorf("Credential() = %v, want %v", got, overrideCred)
+	}
+}
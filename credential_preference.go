@@ -0,0 +1,105 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"fmt"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// CredentialPreference selects which fields of a credential holding both a
+// username and password and a token are returned.
+type CredentialPreference int
+
+const (
+	// PreferNone returns all the fields of a credential, leaving the choice
+	// to the auth client.
+	PreferNone CredentialPreference = iota
+	// PreferToken drops the username and password of a credential that also
+	// holds a refresh token or an access token.
+	PreferToken
+	// PreferBasic drops the refresh token and access token of a credential
+	// that also holds a username or a password.
+	PreferBasic
+)
+
+// String returns the string representation of the CredentialPreference.
+func (p CredentialPreference) String() string {
+	switch p {
+	case PreferNone:
+		return "none"
+	case PreferToken:
+		return "token"
+	case PreferBasic:
+		return "basic"
+	default:
+		return fmt.Sprintf("CredentialPreference(%d)", int(p))
+	}
+}
+
+// preferenceStore is a store filtering the credentials it returns.
+type preferenceStore struct {
+	inner      Store
+	preference CredentialPreference
+}
+
+// NewCredentialPreferenceStore returns a store whose Get() filters the
+// credentials returned by the inner store according to preference. Docker
+// may store both an identity token and a username and password for the same
+// registry, and some registries fail when both are sent, so this allows
+// callers to pick the one that works.
+//
+// Put() and Delete() are passed to the inner store as is.
+func NewCredentialPreferenceStore(inner Store, preference CredentialPreference) Store {
+	return &preferenceStore{
+		inner:      inner,
+		preference: preference,
+	}
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (ps *preferenceStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	cred, err := ps.inner.Get(ctx, serverAddress)
+	if err != nil {
+		return auth.EmptyCredential, err
+	}
+	hasBasic := cred.Username != "" || cred.Password != ""
+	hasToken := cred.RefreshToken != "" || cred.AccessToken != ""
+	if !hasBasic || !hasToken {
+		return cred, nil
+	}
+	switch ps.preference {
+	case PreferToken:
+		cred.Username = ""
+		cred.Password = ""
+	case PreferBasic:
+		cred.RefreshToken = ""
+		cred.AccessToken = ""
+	}
+	return cred, nil
+}
+
+// Put saves credentials into the store for the given server address.
+func (ps *preferenceStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	return ps.inner.Put(ctx, serverAddress, cred)
+}
+
+// Delete removes credentials from the store for the given server address.
+func (ps *preferenceStore) Delete(ctx context.Context, serverAddress string) error {
+	return ps.inner.Delete(ctx, serverAddress)
+}
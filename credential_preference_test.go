@@ -0,0 +1,130 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestCredentialPreferenceStore_Get(t *testing.T) {
+	mixed := auth.Credential{
+		Username:     "username",
+		Password:     "password",
+		RefreshToken: "identity_token",
+		AccessToken:  "access_token",
+	}
+	basic := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	token := auth.Credential{
+		RefreshToken: "identity_token",
+	}
+	tests := []struct {
+		name       string
+		preference CredentialPreference
+		cred       auth.Credential
+		want       auth.Credential
+	}{
+		{
+			name:       "no preference",
+			preference: PreferNone,
+			cred:       mixed,
+			want:       mixed,
+		},
+		{
+			name:       "prefer token",
+			preference: PreferToken,
+			cred:       mixed,
+			want: auth.Credential{
+				RefreshToken: "identity_token",
+				AccessToken:  "access_token",
+			},
+		},
+		{
+			name:       "prefer basic",
+			preference: PreferBasic,
+			cred:       mixed,
+			want:       basic,
+		},
+		{
+			name:       "prefer token with basic credential only",
+			preference: PreferToken,
+			cred:       basic,
+			want:       basic,
+		},
+		{
+			name:       "prefer basic with token only",
+			preference: PreferBasic,
+			cred:       token,
+			want:       token,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			serverAddress := "registry.example.com"
+			ms := NewMemoryStore()
+			if err := ms.Put(ctx, serverAddress, tt.cred); err != nil {
+				t.Fatal("MemoryStore.Put() error =", err)
+			}
+			ps := NewCredentialPreferenceStore(ms, tt.preference)
+			got, err := ps.Get(ctx, serverAddress)
+			if err != nil {
+				t.Fatal("preferenceStore.Get() error =", err)
+			}
+			if got != tt.want {
+				t.Errorf("preferenceStore.Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCredentialPreference_String(t *testing.T) {
+	tests := []struct {
+		preference CredentialPreference
+		want       string
+	}{
+		{PreferNone, "none"},
+		{PreferToken, "token"},
+		{PreferBasic, "basic"},
+		{CredentialPreference(42), "CredentialPreference(42)"},
+	}
+	for _, tt := range tests {
+		if got := tt.preference.String(); got != tt.want {
+			t.Errorf("CredentialPreference.String() = %v, want %v", got, tt.want)
+		}
+	}
+}
+
+func TestCredentialPreferenceStore_badStore(t *testing.T) {
+	ctx := context.Background()
+	ps := NewCredentialPreferenceStore(&badStore{}, PreferToken)
+	serverAddress := "registry.example.com"
+	if _, err := ps.Get(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("preferenceStore.Get() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := ps.Put(ctx, serverAddress, auth.EmptyCredential); !errors.Is(err, errBadStore) {
+		t.Errorf("preferenceStore.Put() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := ps.Delete(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("preferenceStore.Delete() error = %v, wantErr %v", err, errBadStore)
+	}
+}
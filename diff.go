@@ -0,0 +1,89 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"fmt"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// DiffResult classifies how the credentials of a server address compare
+// between two stores.
+type DiffResult int
+
+const (
+	// DiffEqual indicates that both stores hold the same credential, or that
+	// neither store holds any credential.
+	DiffEqual DiffResult = iota
+	// DiffMissingInA indicates that only the second store holds a credential.
+	DiffMissingInA
+	// DiffMissingInB indicates that only the first store holds a credential.
+	DiffMissingInB
+	// DiffDiffers indicates that both stores hold a credential but the
+	// credentials are different.
+	DiffDiffers
+)
+
+// String returns the string representation of the DiffResult.
+func (r DiffResult) String() string {
+	switch r {
+	case DiffEqual:
+		return "equal"
+	case DiffMissingInA:
+		return "missing in a"
+	case DiffMissingInB:
+		return "missing in b"
+	case DiffDiffers:
+		return "differs"
+	default:
+		return fmt.Sprintf("DiffResult(%d)", int(r))
+	}
+}
+
+// Diff retrieves the credentials of each of the given server addresses from
+// the stores a and b, and reports how they compare. The returned map is keyed
+// by server address and only holds the classification, never the secrets.
+func Diff(ctx context.Context, a, b Store, serverAddresses []string) (map[string]DiffResult, error) {
+	results := make(map[string]DiffResult, len(serverAddresses))
+	for _, serverAddress := range serverAddresses {
+		credA, err := a.Get(ctx, serverAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the credential for %s from store a: %w", serverAddress, err)
+		}
+		credB, err := b.Get(ctx, serverAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the credential for %s from store b: %w", serverAddress, err)
+		}
+		results[serverAddress] = diffCredential(credA, credB)
+	}
+	return results, nil
+}
+
+// diffCredential classifies the difference between credA and credB.
+func diffCredential(credA, credB auth.Credential) DiffResult {
+	switch {
+	case credA == credB:
+		return DiffEqual
+	case credA == auth.EmptyCredential:
+		return DiffMissingInA
+	case credB == auth.EmptyCredential:
+		return DiffMissingInB
+	default:
+		return DiffDiffers
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestDiff(t *testing.T) {
+	ctx := context.Background()
+	a := NewMemoryStore()
+	b := NewMemoryStore()
+
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	otherCred := auth.Credential{
+		RefreshToken: "identity_token",
+	}
+	if err := a.Put(ctx, "equal.example.com", cred); err != nil {
+		t.Fatal("MemoryStore.Put() error =", err)
+	}
+	if err := b.Put(ctx, "equal.example.com", cred); err != nil {
+		t.Fatal("MemoryStore.Put() error =", err)
+	}
+	if err := b.Put(ctx, "only-b.example.com", cred); err != nil {
+		t.Fatal("MemoryStore.Put() error =", err)
+	}
+	if err := a.Put(ctx, "only-a.example.com", cred); err != nil {
+		t.Fatal("MemoryStore.Put() error =", err)
+	}
+	if err := a.Put(ctx, "differs.example.com", cred); err != nil {
+		t.Fatal("MemoryStore.Put() error =", err)
+	}
+	if err := b.Put(ctx, "differs.example.com", otherCred); err != nil {
+		t.Fatal("MemoryStore.Put() error =", err)
+	}
+
+	serverAddresses := []string{
+		"equal.example.com",
+		"only-b.example.com",
+		"only-a.example.com",
+		"differs.example.com",
+		"none.example.com",
+	}
+	got, err := Diff(ctx, a, b, serverAddresses)
+	if err != nil {
+		t.Fatal("Diff() error =", err)
+	}
+	want := map[string]DiffResult{
+		"equal.example.com":   DiffEqual,
+		"only-b.example.com":  DiffMissingInA,
+		"only-a.example.com":  DiffMissingInB,
+		"differs.example.com": DiffDiffers,
+		"none.example.com":    DiffEqual,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %v, want %v", got, want)
+	}
+}
+
+func TestDiff_badStore(t *testing.T) {
+	ctx := context.Background()
+	ms := NewMemoryStore()
+	serverAddresses := []string{"registry.example.com"}
+
+	if _, err := Diff(ctx, &badStore{}, ms, serverAddresses); !errors.Is(err, errBadStore) {
+		t.Errorf("Diff() error = %v, want %v", err, errBadStore)
+	}
+	if _, err := Diff(ctx, ms, &badStore{}, serverAddresses); !errors.Is(err, errBadStore) {
+		t.Errorf("Diff() error = %v, want %v", err, errBadStore)
+	}
+}
+
+func TestDiffResult_String(t *testing.T) {
+	tests := []struct {
+		result DiffResult
+		want   string
+	}{
+		{DiffEqual, "equal"},
+		{DiffMissingInA, "missing in a"},
+		{DiffMissingInB, "missing in b"},
+		{DiffDiffers, "differs"},
+		{DiffResult(42), "DiffResult(42)"},
+	}
+	for _, tt := range tests {
+		if got := tt.result.String(); got != tt.want {
+			t.Errorf("DiffResult.String() = %v, want %v", got, tt.want)
+		}
+	}
+}
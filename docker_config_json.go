@@ -0,0 +1,65 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// ExportDockerConfigJSON retrieves the credentials of each of the given server
+// addresses from store, and encodes them in the .dockerconfigjson format
+// expected by Kubernetes for secrets of the type
+// "kubernetes.io/dockerconfigjson":
+//
+//	{"auths": {"<server address>": {"username": "...", "password": "...", "auth": "..."}}}
+//
+// The "auth" field is base64(username:password), and the refresh token and
+// access token, if any, are exported as the "identitytoken" and
+// "registrytoken" fields respectively. Server addresses without credentials
+// in store are skipped. As store.Get() is used, the exported keys are the
+// server addresses as given, so "https://index.docker.io/v1/" should be
+// passed for Docker Hub.
+//
+// The output contains the secrets in plaintext and should be handled with
+// the same care as the credentials themselves.
+func ExportDockerConfigJSON(ctx context.Context, store Store, serverAddresses []string) ([]byte, error) {
+	authConfigs := make(map[string]authConfig, len(serverAddresses))
+	for _, serverAddress := range serverAddresses {
+		cred, err := store.Get(ctx, serverAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the credential for %s: %w", serverAddress, err)
+		}
+		if cred == auth.EmptyCredential {
+			continue
+		}
+		authConfigs[serverAddress] = authConfig{
+			Auth:          encodeAuth(cred.Username, cred.Password),
+			IdentityToken: cred.RefreshToken,
+			RegistryToken: cred.AccessToken,
+			Username:      cred.Username,
+			Password:      cred.Password,
+		}
+	}
+	return json.Marshal(struct {
+		AuthConfigs map[string]authConfig `json:"auths"`
+	}{
+		AuthConfigs: authConfigs,
+	})
+}
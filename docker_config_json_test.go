@@ -0,0 +1,85 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/oras-project/oras-credentials-go/internal/config/configtest"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestExportDockerConfigJSON(t *testing.T) {
+	ctx := context.Background()
+	ms := NewMemoryStore()
+	if err := ms.Put(ctx, "basic.example.com", auth.Credential{
+		Username: "username",
+		Password: "password",
+	}); err != nil {
+		t.Fatal("MemoryStore.Put() error =", err)
+	}
+	if err := ms.Put(ctx, "token.example.com", auth.Credential{
+		RefreshToken: "identity_token",
+		AccessToken:  "access_token",
+	}); err != nil {
+		t.Fatal("MemoryStore.Put() error =", err)
+	}
+
+	serverAddresses := []string{"basic.example.com", "token.example.com", "missing.example.com"}
+	got, err := ExportDockerConfigJSON(ctx, ms, serverAddresses)
+	if err != nil {
+		t.Fatal("ExportDockerConfigJSON() error =", err)
+	}
+	var gotCfg configtest.Config
+	if err := json.Unmarshal(got, &gotCfg); err != nil {
+		t.Fatalf("failed to unmarshal exported config: %v", err)
+	}
+	want := map[string]configtest.AuthConfig{
+		"basic.example.com": {
+			Username: "username",
+			Password: "password",
+			Auth:     "dXNlcm5hbWU6cGFzc3dvcmQ=",
+		},
+		"token.example.com": {
+			IdentityToken: "identity_token",
+			RegistryToken: "access_token",
+		},
+	}
+	if !reflect.DeepEqual(gotCfg.AuthConfigs, want) {
+		t.Errorf("ExportDockerConfigJSON() auths = %v, want %v", gotCfg.AuthConfigs, want)
+	}
+}
+
+func TestExportDockerConfigJSON_empty(t *testing.T) {
+	got, err := ExportDockerConfigJSON(context.Background(), NewMemoryStore(), nil)
+	if err != nil {
+		t.Fatal("ExportDockerConfigJSON() error =", err)
+	}
+	if want := `{"auths":{}}`; string(got) != want {
+		t.Errorf("ExportDockerConfigJSON() = %s, want %s", got, want)
+	}
+}
+
+func TestExportDockerConfigJSON_badStore(t *testing.T) {
+	_, err := ExportDockerConfigJSON(context.Background(), &badStore{}, []string{"registry.example.com"})
+	if !errors.Is(err, errBadStore) {
+		t.Errorf("ExportDockerConfigJSON() error = %v, wantErr %v", err, errBadStore)
+	}
+}
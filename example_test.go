@@ -41,7 +41,7 @@ func ExampleNewNativeStore() {
 	if err != nil {
 		panic(err)
 	}
-	fmt.Println(cred)
+	fmt.Println(credentials.Redact(cred))
 
 	// delete the credentials from the store
 	err = ns.Delete(ctx, "localhost:5000")
@@ -71,7 +71,7 @@ func ExampleNewFileStore() {
 	if err != nil {
 		panic(err)
 	}
-	fmt.Println(cred)
+	fmt.Println(credentials.Redact(cred))
 
 	// delete the credentials from the store
 	err = fs.Delete(ctx, "localhost:5000")
@@ -109,7 +109,7 @@ func ExampleNewStore() {
 	if err != nil {
 		panic(err)
 	}
-	fmt.Println(cred)
+	fmt.Println(credentials.Redact(cred))
 
 	// delete the credentials from the store
 	err = store.Delete(ctx, "localhost:5000")
@@ -141,7 +141,7 @@ func ExampleNewStoreFromDocker() {
 	if err != nil {
 		panic(err)
 	}
-	fmt.Println(cred)
+	fmt.Println(credentials.Redact(cred))
 
 	// delete the credentials from the store
 	err = ds.Delete(ctx, "localhost:5000")
@@ -175,7 +175,7 @@ func ExampleNewStoreWithFallbacks_configAsPrimaryStoreDockerAsFallback() {
 	if err != nil {
 		panic(err)
 	}
-	fmt.Println(cred)
+	fmt.Println(credentials.Redact(cred))
 
 	// delete the credentials from the store
 	err = sf.Delete(ctx, "localhost:5000")
@@ -206,6 +206,48 @@ func ExampleLogin() {
 	fmt.Println("Login succeeded")
 }
 
+func ExampleLoginWithOptions() {
+	store, err := credentials.NewStore("example/path/config.json", credentials.StoreOptions{
+		AllowPlaintextPut: true,
+	})
+	if err != nil {
+		panic(err)
+	}
+	registry, err := remote.NewRegistry("localhost:5000")
+	if err != nil {
+		panic(err)
+	}
+	cred := auth.Credential{
+		Username: "username-example",
+		Password: "password-example",
+	}
+	// The registry exposes the API under the "/prefix/v2/" root instead of
+	// the standard "/v2/" root, so validate the credentials against it.
+	opts := credentials.LoginOptions{
+		Ping: func(ctx context.Context, reg *remote.Registry) error {
+			url := "https://" + reg.Reference.Registry + "/prefix/v2/"
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			if err != nil {
+				return err
+			}
+			resp, err := reg.Client.Do(req)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+			}
+			return nil
+		},
+	}
+	err = credentials.LoginWithOptions(context.Background(), store, registry, cred, opts)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println("Login succeeded")
+}
+
 func ExampleLogout() {
 	store, err := credentials.NewStore("example/path/config.json", credentials.StoreOptions{})
 	if err != nil {
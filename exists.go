@@ -0,0 +1,47 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// ExistenceChecker is an optional interface that a Store can implement to
+// check whether credentials exist more cheaply than by retrieving them.
+type ExistenceChecker interface {
+	// Exists returns true if non-empty credentials are stored for the given
+	// server address.
+	Exists(ctx context.Context, serverAddress string) (bool, error)
+}
+
+// Exists returns whether non-empty credentials are stored in s for the given
+// server address, which is convenient for writing idempotent login flows. If
+// s implements [ExistenceChecker], its Exists method is used. Otherwise, the
+// credentials are retrieved with Get() and checked against
+// auth.EmptyCredential, which is the case for the stores returned by
+// [NewStore], [NewFileStore] and [NewNativeStore].
+func Exists(ctx context.Context, s Store, serverAddress string) (bool, error) {
+	if checker, ok := s.(ExistenceChecker); ok {
+		return checker.Exists(ctx, serverAddress)
+	}
+	cred, err := s.Get(ctx, serverAddress)
+	if err != nil {
+		return false, err
+	}
+	return cred != auth.EmptyCredential, nil
+}
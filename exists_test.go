@@ -0,0 +1,126 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oras-project/oras-credentials-go/internal/config/configtest"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestExists(t *testing.T) {
+	ctx := context.Background()
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	tests := []struct {
+		name  string
+		store Store
+	}{
+		{
+			name:  "store not implementing ExistenceChecker",
+			store: NewMemoryStore(),
+		},
+		{
+			name:  "store implementing ExistenceChecker",
+			store: NewLockedMemoryStore(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.store.Put(ctx, "basic.example.com", cred); err != nil {
+				t.Fatal("Store.Put() error =", err)
+			}
+			if err := tt.store.Put(ctx, "empty.example.com", auth.EmptyCredential); err != nil {
+				t.Fatal("Store.Put() error =", err)
+			}
+			for serverAddress, want := range map[string]bool{
+				"basic.example.com":   true,
+				"empty.example.com":   false,
+				"missing.example.com": false,
+			} {
+				got, err := Exists(ctx, tt.store, serverAddress)
+				if err != nil {
+					t.Fatal("Exists() error =", err)
+				}
+				if got != want {
+					t.Errorf("Exists(%s) = %v, want %v", serverAddress, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestExists_badStore(t *testing.T) {
+	if _, err := Exists(context.Background(), &badStore{}, "registry.example.com"); !errors.Is(err, errBadStore) {
+		t.Errorf("Exists() error = %v, wantErr %v", err, errBadStore)
+	}
+}
+
+func TestExists_dynamicStore(t *testing.T) {
+	// prepare test content
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.json")
+	cfg := configtest.Config{
+		AuthConfigs: map[string]configtest.AuthConfig{
+			"basic.example.com": {
+				Auth: "dXNlcm5hbWU6cGFzc3dvcmQ=",
+			},
+			"empty.example.com": {},
+		},
+		CredentialHelpers: map[string]string{
+			"helper.example.com": "nonexistent",
+		},
+	}
+	jsonStr, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("failed to marshal config: %v", err)
+	}
+	if err := os.WriteFile(configPath, jsonStr, 0666); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	ds, err := NewStore(configPath, StoreOptions{})
+	if err != nil {
+		t.Fatal("NewStore() error =", err)
+	}
+
+	ctx := context.Background()
+	for serverAddress, want := range map[string]bool{
+		"basic.example.com":   true,
+		"empty.example.com":   false,
+		"missing.example.com": false,
+	} {
+		got, err := Exists(ctx, ds, serverAddress)
+		if err != nil {
+			t.Fatal("Exists() error =", err)
+		}
+		if got != want {
+			t.Errorf("Exists(%s) = %v, want %v", serverAddress, got, want)
+		}
+	}
+
+	// credential helpers are checked with Get(), which fails for a missing helper
+	if _, err := Exists(ctx, ds, "helper.example.com"); err == nil {
+		t.Error("Exists() error = nil, want error")
+	}
+}
@@ -16,9 +16,19 @@ limitations under the License.
 package credentials
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
 	"oras.land/oras-go/v2/registry/remote/credentials"
 )
 
+// ingestFilePattern is the pattern of the temporary ingest files created when
+// saving a config file.
+const ingestFilePattern = "oras_credstore_temp_*"
+
 // FileStore implements a credentials store using the docker configuration file
 // to keep the credentials in plain-text.
 //
@@ -56,3 +66,46 @@ var (
 func NewFileStore(configPath string) (*FileStore, error) {
 	return credentials.NewFileStore(configPath)
 }
+
+// CleanupStaleIngestFiles removes the temporary ingest files left in configDir
+// that are older than olderThan, and returns the number of removed files.
+//
+// Config files are saved by writing into a temporary ingest file named
+// "oras_credstore_temp_{randomString}" in the same directory, which is then
+// renamed to the config file. If the process crashes in between, the ingest
+// file is left behind. A non-existing configDir is not an error.
+func CleanupStaleIngestFiles(configDir string, olderThan time.Duration) (removed int, err error) {
+	entries, err := os.ReadDir(configDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read directory %s: %w", configDir, err)
+	}
+	threshold := time.Now().Add(-olderThan)
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		if matched, _ := filepath.Match(ingestFilePattern, entry.Name()); !matched {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				// removed concurrently
+				continue
+			}
+			return removed, fmt.Errorf("failed to stat ingest file %s: %w", entry.Name(), err)
+		}
+		if !info.ModTime().Before(threshold) {
+			continue
+		}
+		path := filepath.Join(configDir, entry.Name())
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return removed, fmt.Errorf("failed to remove ingest file %s: %w", path, err)
+		}
+		removed++
+	}
+	return removed, nil
+}
@@ -23,6 +23,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/oras-project/oras-credentials-go/internal/config/configtest"
 	"oras.land/oras-go/v2/registry/remote/auth"
@@ -182,6 +183,47 @@ func TestFileStore_Get_validConfig(t *testing.T) {
 	}
 }
 
+func TestFileStore_Get_serverAddressConfig(t *testing.T) {
+	ctx := context.Background()
+	fs, err := NewFileStore("testdata/serveraddress_auths_config.json")
+	if err != nil {
+		t.Fatal("NewFileStore() error =", err)
+	}
+
+	tests := []struct {
+		name          string
+		serverAddress string
+		want          auth.Credential
+		wantErr       bool
+	}{
+		{
+			name:          "Nested serveraddress ignored in favor of the key",
+			serverAddress: "registry1.example.com",
+			want: auth.Credential{
+				Username: "username",
+				Password: "password",
+			},
+		},
+		{
+			name:          "Nested serveraddress not used as a key",
+			serverAddress: "registry2.example.com",
+			want:          auth.EmptyCredential,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fs.Get(ctx, tt.serverAddress)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FileStore.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FileStore.Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFileStore_Get_invalidConfig(t *testing.T) {
 	ctx := context.Background()
 	fs, err := NewFileStore("testdata/invalid_auths_entry_config.json")
@@ -877,3 +919,65 @@ func TestFileStore_Delete_notExistConfig(t *testing.T) {
 		t.Errorf("Stat(%s) error = %v, wantErr %v", configPath, err, wantErr)
 	}
 }
+
+func TestCleanupStaleIngestFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	old := time.Now().Add(-2 * time.Hour)
+	files := []struct {
+		name    string
+		stale   bool
+		removed bool
+	}{
+		{name: "oras_credstore_temp_111", stale: true, removed: true},
+		{name: "oras_credstore_temp_222", stale: true, removed: true},
+		{name: "oras_credstore_temp_333", stale: false, removed: false},
+		{name: "config.json", stale: true, removed: false},
+		{name: "other_temp_444", stale: true, removed: false},
+	}
+	for _, f := range files {
+		path := filepath.Join(tempDir, f.name)
+		if err := os.WriteFile(path, []byte("{}"), 0600); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+		if f.stale {
+			if err := os.Chtimes(path, old, old); err != nil {
+				t.Fatalf("failed to change file times: %v", err)
+			}
+		}
+	}
+	// directories matching the pattern should be left alone
+	staleDir := filepath.Join(tempDir, "oras_credstore_temp_dir")
+	if err := os.Mkdir(staleDir, 0700); err != nil {
+		t.Fatalf("failed to make directory: %v", err)
+	}
+	if err := os.Chtimes(staleDir, old, old); err != nil {
+		t.Fatalf("failed to change directory times: %v", err)
+	}
+
+	removed, err := CleanupStaleIngestFiles(tempDir, time.Hour)
+	if err != nil {
+		t.Fatal("CleanupStaleIngestFiles() error =", err)
+	}
+	if want := 2; removed != want {
+		t.Errorf("CleanupStaleIngestFiles() = %v, want %v", removed, want)
+	}
+	for _, f := range files {
+		_, err := os.Stat(filepath.Join(tempDir, f.name))
+		if gotRemoved := errors.Is(err, os.ErrNotExist); gotRemoved != f.removed {
+			t.Errorf("file %s removed = %v, want %v", f.name, gotRemoved, f.removed)
+		}
+	}
+	if _, err := os.Stat(staleDir); err != nil {
+		t.Errorf("directory %s should not be removed: %v", staleDir, err)
+	}
+}
+
+func TestCleanupStaleIngestFiles_notExistDir(t *testing.T) {
+	removed, err := CleanupStaleIngestFiles(filepath.Join(t.TempDir(), "whatever"), time.Hour)
+	if err != nil {
+		t.Fatal("CleanupStaleIngestFiles() error =", err)
+	}
+	if removed != 0 {
+		t.Errorf("CleanupStaleIngestFiles() = %v, want 0", removed)
+	}
+}
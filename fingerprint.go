@@ -0,0 +1,50 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// fingerprintKey is the HMAC key of CredentialFingerprint. It is fixed so that
+// fingerprints are comparable across processes, and only separates them from
+// other hashes of the same fields.
+var fingerprintKey = []byte("oras-credentials-go/fingerprint/v1")
+
+// CredentialFingerprint returns a stable fingerprint of the given credential,
+// as the hex-encoded HMAC-SHA256 of all its fields. Equal credentials have
+// equal fingerprints, across stores and processes, so that sync tools can
+// detect changed credentials without comparing the secrets themselves.
+//
+// As the HMAC key is public, a low-entropy secret can be recovered from its
+// fingerprint by brute force, so fingerprints must be handled as carefully as
+// the credentials: they are not meant to be logged or displayed.
+func CredentialFingerprint(cred auth.Credential) string {
+	mac := hmac.New(sha256.New, fingerprintKey)
+	for _, field := range []string{cred.Username, cred.Password, cred.RefreshToken, cred.AccessToken} {
+		// length-prefix the fields so that their boundaries are unambiguous
+		var size [8]byte
+		binary.BigEndian.PutUint64(size[:], uint64(len(field)))
+		mac.Write(size[:])
+		mac.Write([]byte(field))
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,57 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"strings"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestCredentialFingerprint(t *testing.T) {
+	cred := auth.Credential{
+		Username:     "goodbye",
+		Password:     "hello",
+		RefreshToken: "identity_token",
+		AccessToken:  "registry_token",
+	}
+	got := CredentialFingerprint(cred)
+	if len(got) != 64 {
+		t.Errorf("CredentialFingerprint() = %v, want 64 hex characters", got)
+	}
+	for _, secret := range []string{cred.Password, cred.RefreshToken, cred.AccessToken} {
+		if strings.Contains(got, secret) {
+			t.Errorf("CredentialFingerprint() = %v, contains secret %q", got, secret)
+		}
+	}
+	if again := CredentialFingerprint(cred); again != got {
+		t.Errorf("CredentialFingerprint() = %v, want stable %v", again, got)
+	}
+
+	// changing any field, or moving a field boundary, changes the fingerprint
+	for _, other := range []auth.Credential{
+		auth.EmptyCredential,
+		{Username: "goodbye", Password: "hello", RefreshToken: "identity_token"},
+		{Username: "goodbye", Password: "HELLO", RefreshToken: "identity_token", AccessToken: "registry_token"},
+		{Username: "goodbyeh", Password: "ello", RefreshToken: "identity_token", AccessToken: "registry_token"},
+		{Username: "goodbye", Password: "hello", RefreshToken: "registry_token", AccessToken: "identity_token"},
+	} {
+		if CredentialFingerprint(other) == got {
+			t.Errorf("CredentialFingerprint(%v) = %v, want different from CredentialFingerprint(%v)", Redact(other), got, Redact(cred))
+		}
+	}
+}
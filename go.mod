@@ -2,10 +2,12 @@ module github.com/oras-project/oras-credentials-go
 
 go 1.19
 
-require oras.land/oras-go/v2 v2.4.0
+require (
+	golang.org/x/sync v0.6.0
+	oras.land/oras-go/v2 v2.4.0
+)
 
 require (
 	github.com/opencontainers/go-digest v1.0.0 // indirect
 	github.com/opencontainers/image-spec v1.1.0-rc6 // indirect
-	golang.org/x/sync v0.6.0 // indirect
 )
@@ -0,0 +1,46 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// ErrInvalidHelperSuffix is returned by ValidateHelperSuffix when the
+// credential helper suffix is invalid.
+var ErrInvalidHelperSuffix = errors.New("invalid credential helper suffix")
+
+// helperSuffixRegexp matches the allowed credential helper suffixes.
+// Dots are allowed for helpers such as "desktop.exe" used by Docker Desktop
+// on WSL.
+var helperSuffixRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]+(\.[a-zA-Z0-9_-]+)*$`)
+
+// ValidateHelperSuffix validates that the given credential helper suffix, as
+// found in the "credsStore" and "credHelpers" fields of a docker config
+// file, is safe to be used to construct the name of the helper binary
+// "docker-credential-<suffix>".
+//
+// A valid suffix consists of letters, digits, underscores and hyphens,
+// optionally separated by single dots. Path separators, spaces and empty
+// suffixes are rejected.
+func ValidateHelperSuffix(suffix string) error {
+	if !helperSuffixRegexp.MatchString(suffix) {
+		return fmt.Errorf("%w: %q", ErrInvalidHelperSuffix, suffix)
+	}
+	return nil
+}
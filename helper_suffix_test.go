@@ -0,0 +1,53 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateHelperSuffix(t *testing.T) {
+	tests := []struct {
+		suffix  string
+		wantErr bool
+	}{
+		{suffix: "pass"},
+		{suffix: "osxkeychain"},
+		{suffix: "ecr-login"},
+		{suffix: "acr_env"},
+		{suffix: "desktop.exe"},
+		{suffix: "", wantErr: true},
+		{suffix: "../../bin/sh", wantErr: true},
+		{suffix: "helper/evil", wantErr: true},
+		{suffix: `helper\evil`, wantErr: true},
+		{suffix: "my helper", wantErr: true},
+		{suffix: ".hidden", wantErr: true},
+		{suffix: "helper.", wantErr: true},
+		{suffix: "helper;rm", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.suffix, func(t *testing.T) {
+			err := ValidateHelperSuffix(tt.suffix)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateHelperSuffix() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrInvalidHelperSuffix) {
+				t.Errorf("ValidateHelperSuffix() error = %v, want %v", err, ErrInvalidHelperSuffix)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// KV is a minimal key-value storage backend, such as BoltDB or Redis, that
+// can be used to keep credentials via NewKVStore.
+type KV interface {
+	// Get returns the value of the given key and whether the key exists.
+	Get(key string) ([]byte, bool, error)
+	// Set sets the value of the given key.
+	Set(key string, val []byte) error
+	// Del deletes the given key. Deleting a non-existing key is not an error.
+	Del(key string) error
+}
+
+// kvStore implements a credentials store backed by a KV.
+type kvStore struct {
+	kv KV
+}
+
+// NewKVStore returns a store that keeps credentials in the given KV, keyed by
+// server address. Credentials are serialized as JSON in the same format as an
+// entry of the "auths" field of a docker config file.
+func NewKVStore(kv KV) Store {
+	return &kvStore{kv: kv}
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (ks *kvStore) Get(_ context.Context, serverAddress string) (auth.Credential, error) {
+	val, ok, err := ks.kv.Get(serverAddress)
+	if err != nil {
+		return auth.EmptyCredential, err
+	}
+	if !ok {
+		return auth.EmptyCredential, nil
+	}
+	var ac authConfig
+	if err := json.Unmarshal(val, &ac); err != nil {
+		return auth.EmptyCredential, fmt.Errorf("failed to unmarshal the credential for %s: %w", serverAddress, err)
+	}
+	return ac.credential()
+}
+
+// Put saves credentials into the store for the given server address.
+// Put returns ErrBadCredentialFormat if the username contains colons.
+func (ks *kvStore) Put(_ context.Context, serverAddress string, cred auth.Credential) error {
+	if err := validateCredentialFormat(cred); err != nil {
+		return err
+	}
+	val, err := json.Marshal(newAuthConfig(cred))
+	if err != nil {
+		return fmt.Errorf("failed to marshal the credential for %s: %w", serverAddress, err)
+	}
+	return ks.kv.Set(serverAddress, val)
+}
+
+// Delete removes credentials from the store for the given server address.
+func (ks *kvStore) Delete(_ context.Context, serverAddress string) error {
+	return ks.kv.Del(serverAddress)
+}
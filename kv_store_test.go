@@ -0,0 +1,152 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// testKV is a map-based KV for testing purpose.
+type testKV map[string][]byte
+
+func (kv testKV) Get(key string) ([]byte, bool, error) {
+	val, ok := kv[key]
+	return val, ok, nil
+}
+
+func (kv testKV) Set(key string, val []byte) error {
+	kv[key] = val
+	return nil
+}
+
+func (kv testKV) Del(key string) error {
+	delete(kv, key)
+	return nil
+}
+
+// badKV is a KV that always fails.
+type badKV struct{}
+
+func (badKV) Get(string) ([]byte, bool, error) { return nil, false, errBadStore }
+func (badKV) Set(string, []byte) error         { return errBadStore }
+func (badKV) Del(string) error                 { return errBadStore }
+
+func TestKVStore(t *testing.T) {
+	ctx := context.Background()
+	kv := testKV{}
+	ks := NewKVStore(kv)
+	serverAddress := "registry.example.com"
+
+	// test get non-existing credential
+	got, err := ks.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("kvStore.Get() error =", err)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("kvStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+
+	// test put
+	cred := auth.Credential{
+		Username:     "username",
+		Password:     "password",
+		RefreshToken: "identity_token",
+		AccessToken:  "registry_token",
+	}
+	if err := ks.Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("kvStore.Put() error =", err)
+	}
+	var gotAuthConfig authConfig
+	if err := json.Unmarshal(kv[serverAddress], &gotAuthConfig); err != nil {
+		t.Fatal("failed to unmarshal stored value:", err)
+	}
+	wantAuthConfig := authConfig{
+		Auth:          "dXNlcm5hbWU6cGFzc3dvcmQ=",
+		IdentityToken: "identity_token",
+		RegistryToken: "registry_token",
+	}
+	if !reflect.DeepEqual(gotAuthConfig, wantAuthConfig) {
+		t.Errorf("stored value = %v, want %v", gotAuthConfig, wantAuthConfig)
+	}
+
+	// test get
+	got, err = ks.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("kvStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("kvStore.Get() = %v, want %v", got, cred)
+	}
+
+	// test delete
+	if err := ks.Delete(ctx, serverAddress); err != nil {
+		t.Fatal("kvStore.Delete() error =", err)
+	}
+	if _, ok := kv[serverAddress]; ok {
+		t.Errorf("kvStore.Delete() did not delete the key %s", serverAddress)
+	}
+}
+
+func TestKVStore_Get_invalidValue(t *testing.T) {
+	ctx := context.Background()
+	kv := testKV{
+		"bad-json.example.com": []byte("whatever"),
+		"bad-auth.example.com": []byte(`{"auth":"whatever"}`),
+	}
+	ks := NewKVStore(kv)
+	for serverAddress := range kv {
+		if _, err := ks.Get(ctx, serverAddress); err == nil {
+			t.Errorf("kvStore.Get(%s) error = nil, want error", serverAddress)
+		}
+	}
+}
+
+func TestKVStore_Put_badCredentialFormat(t *testing.T) {
+	ctx := context.Background()
+	kv := testKV{}
+	ks := NewKVStore(kv)
+	cred := auth.Credential{
+		Username: "user:name",
+		Password: "password",
+	}
+	if err := ks.Put(ctx, "registry.example.com", cred); !errors.Is(err, ErrBadCredentialFormat) {
+		t.Errorf("kvStore.Put() error = %v, wantErr %v", err, ErrBadCredentialFormat)
+	}
+	if len(kv) != 0 {
+		t.Errorf("kvStore.Put() stored %d values, want 0", len(kv))
+	}
+}
+
+func TestKVStore_badKV(t *testing.T) {
+	ctx := context.Background()
+	ks := NewKVStore(badKV{})
+	serverAddress := "registry.example.com"
+	if _, err := ks.Get(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("kvStore.Get() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := ks.Put(ctx, serverAddress, auth.Credential{Username: "username"}); !errors.Is(err, errBadStore) {
+		t.Errorf("kvStore.Put() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := ks.Delete(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("kvStore.Delete() error = %v, wantErr %v", err, errBadStore)
+	}
+}
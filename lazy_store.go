@@ -0,0 +1,79 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+
+	"golang.org/x/sync/singleflight"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// lazyStore is a store that fetches credentials on demand and memoizes them.
+type lazyStore struct {
+	fetch func(context.Context, string) (auth.Credential, error)
+	cache Store
+	group singleflight.Group
+}
+
+// NewLazyStore returns a store that fetches credentials on demand using the
+// given fetch function.
+//   - Get() returns the memoized credential of the server address if any.
+//     Otherwise, it calls fetch and memoizes the non-empty result. Concurrent
+//     calls of Get() for the same server address share a single call of
+//     fetch, which is made with the context of the first caller.
+//   - Put() memoizes the given credential without calling fetch.
+//   - Delete() removes the memoized credential so that the next Get() calls
+//     fetch again.
+func NewLazyStore(fetch func(ctx context.Context, serverAddress string) (auth.Credential, error)) Store {
+	return &lazyStore{
+		fetch: fetch,
+		cache: NewMemoryStore(),
+	}
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (ls *lazyStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	if cred, err := ls.cache.Get(ctx, serverAddress); err != nil || cred != auth.EmptyCredential {
+		return cred, err
+	}
+	v, err, _ := ls.group.Do(serverAddress, func() (interface{}, error) {
+		cred, err := ls.fetch(ctx, serverAddress)
+		if err != nil {
+			return auth.EmptyCredential, err
+		}
+		if cred != auth.EmptyCredential {
+			if err := ls.cache.Put(ctx, serverAddress, cred); err != nil {
+				return auth.EmptyCredential, err
+			}
+		}
+		return cred, nil
+	})
+	if err != nil {
+		return auth.EmptyCredential, err
+	}
+	return v.(auth.Credential), nil
+}
+
+// Put saves credentials into the store for the given server address.
+func (ls *lazyStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	return ls.cache.Put(ctx, serverAddress, cred)
+}
+
+// Delete removes credentials from the store for the given server address.
+func (ls *lazyStore) Delete(ctx context.Context, serverAddress string) error {
+	return ls.cache.Delete(ctx, serverAddress)
+}
@@ -0,0 +1,152 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestLazyStore_Get_memoized(t *testing.T) {
+	ctx := context.Background()
+	want := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	var count int32
+	ls := NewLazyStore(func(ctx context.Context, serverAddress string) (auth.Credential, error) {
+		atomic.AddInt32(&count, 1)
+		return want, nil
+	})
+
+	serverAddress := "registry.example.com"
+	for i := 0; i < 3; i++ {
+		got, err := ls.Get(ctx, serverAddress)
+		if err != nil {
+			t.Fatal("lazyStore.Get() error =", err)
+		}
+		if got != want {
+			t.Errorf("lazyStore.Get() = %v, want %v", got, want)
+		}
+	}
+	if got := atomic.LoadInt32(&count); got != 1 {
+		t.Errorf("fetch called %d times, want 1", got)
+	}
+
+	// Delete should force a new fetch
+	if err := ls.Delete(ctx, serverAddress); err != nil {
+		t.Fatal("lazyStore.Delete() error =", err)
+	}
+	if _, err := ls.Get(ctx, serverAddress); err != nil {
+		t.Fatal("lazyStore.Get() error =", err)
+	}
+	if got := atomic.LoadInt32(&count); got != 2 {
+		t.Errorf("fetch called %d times, want 2", got)
+	}
+}
+
+func TestLazyStore_Get_concurrent(t *testing.T) {
+	ctx := context.Background()
+	want := auth.Credential{
+		RefreshToken: "identity_token",
+	}
+	var count int32
+	inFlight := make(chan struct{}, 1)
+	release := make(chan struct{})
+	ls := NewLazyStore(func(ctx context.Context, serverAddress string) (auth.Credential, error) {
+		atomic.AddInt32(&count, 1)
+		inFlight <- struct{}{}
+		<-release
+		return want, nil
+	})
+
+	const concurrency = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, concurrency)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := ls.Get(ctx, "registry.example.com")
+			if err == nil && got != want {
+				err = errors.New("unexpected credential")
+			}
+			errs <- err
+		}()
+	}
+	// keep the fetch blocked until the other goroutines wait for it
+	<-inFlight
+	waitForSingleFlightWaiters(t, concurrency-1)
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error("lazyStore.Get() error =", err)
+		}
+	}
+	if got := atomic.LoadInt32(&count); got != 1 {
+		t.Errorf("fetch called %d times, want 1", got)
+	}
+}
+
+func TestLazyStore_Get_fetchError(t *testing.T) {
+	ctx := context.Background()
+	var count int32
+	ls := NewLazyStore(func(ctx context.Context, serverAddress string) (auth.Credential, error) {
+		atomic.AddInt32(&count, 1)
+		return auth.EmptyCredential, errBadStore
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := ls.Get(ctx, "registry.example.com"); !errors.Is(err, errBadStore) {
+			t.Errorf("lazyStore.Get() error = %v, want %v", err, errBadStore)
+		}
+	}
+	// errors should not be memoized
+	if got := atomic.LoadInt32(&count); got != 2 {
+		t.Errorf("fetch called %d times, want 2", got)
+	}
+}
+
+func TestLazyStore_Put(t *testing.T) {
+	ctx := context.Background()
+	ls := NewLazyStore(func(ctx context.Context, serverAddress string) (auth.Credential, error) {
+		t.Fatal("fetch should not be called")
+		return auth.EmptyCredential, nil
+	})
+
+	serverAddress := "registry.example.com"
+	want := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := ls.Put(ctx, serverAddress, want); err != nil {
+		t.Fatal("lazyStore.Put() error =", err)
+	}
+	got, err := ls.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("lazyStore.Get() error =", err)
+	}
+	if got != want {
+		t.Errorf("lazyStore.Get() = %v, want %v", got, want)
+	}
+}
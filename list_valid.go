@@ -0,0 +1,66 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// ListValid returns the sorted server addresses listed by l whose credentials
+// in s are usable, that is, retrieved without error and non-empty. For
+// example, ListValid(ctx, ds, NewConfigLister(configPath)) returns the
+// registries of a docker config file whose credentials can be decoded,
+// filtering out corrupt and empty entries.
+//
+// An error is returned only if l fails to list the server addresses, or if
+// ctx is done.
+func ListValid(ctx context.Context, s Store, l Lister) ([]string, error) {
+	valid, _, err := listByValidity(ctx, s, l)
+	return valid, err
+}
+
+// ListInvalid returns the sorted server addresses listed by l whose
+// credentials in s are not usable, that is, whose retrieval fails or returns
+// empty credentials. It is the counterpart of [ListValid].
+func ListInvalid(ctx context.Context, s Store, l Lister) ([]string, error) {
+	_, invalid, err := listByValidity(ctx, s, l)
+	return invalid, err
+}
+
+// listByValidity partitions the server addresses listed by l based on the
+// usability of their credentials in s.
+func listByValidity(ctx context.Context, s Store, l Lister) (valid, invalid []string, err error) {
+	serverAddresses, err := l.List(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	valid = make([]string, 0, len(serverAddresses))
+	invalid = make([]string, 0, len(serverAddresses))
+	for _, serverAddress := range serverAddresses {
+		cred, err := s.Get(ctx, serverAddress)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, nil, ctxErr
+		}
+		if err != nil || cred == auth.EmptyCredential {
+			invalid = append(invalid, serverAddress)
+			continue
+		}
+		valid = append(valid, serverAddress)
+	}
+	return valid, invalid, nil
+}
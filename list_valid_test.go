@@ -0,0 +1,111 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/oras-project/oras-credentials-go/internal/config/configtest"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestListValid(t *testing.T) {
+	// prepare test content
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.json")
+	cfg := configtest.Config{
+		AuthConfigs: map[string]configtest.AuthConfig{
+			"registry1.example.com": {
+				Auth: "dXNlcm5hbWU6cGFzc3dvcmQ=",
+			},
+			"registry2.example.com": {
+				IdentityToken: "identity_token",
+			},
+			// not base64-encoded
+			"registry3.example.com": {
+				Auth: "username:password",
+			},
+			"registry4.example.com": {},
+		},
+	}
+	jsonStr, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("failed to marshal config: %v", err)
+	}
+	if err := os.WriteFile(configPath, jsonStr, 0666); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	ds, err := NewStore(configPath, StoreOptions{})
+	if err != nil {
+		t.Fatal("NewStore() error =", err)
+	}
+	ctx := context.Background()
+	cl := NewConfigLister(configPath)
+
+	got, err := ListValid(ctx, ds, cl)
+	if err != nil {
+		t.Fatal("ListValid() error =", err)
+	}
+	if want := []string{"registry1.example.com", "registry2.example.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListValid() = %v, want %v", got, want)
+	}
+	if got, err = ListInvalid(ctx, ds, cl); err != nil {
+		t.Fatal("ListInvalid() error =", err)
+	}
+	if want := []string{"registry3.example.com", "registry4.example.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListInvalid() = %v, want %v", got, want)
+	}
+}
+
+func TestListValid_badStore(t *testing.T) {
+	ctx := context.Background()
+	ls := NewLockedMemoryStore()
+	if err := ls.Put(ctx, "registry.example.com", auth.Credential{Username: "username"}); err != nil {
+		t.Fatal("lockedMemoryStore.Put() error =", err)
+	}
+	lister := ls.(Lister)
+
+	// failing retrievals are invalid
+	got, err := ListValid(ctx, &badStore{}, lister)
+	if err != nil {
+		t.Fatal("ListValid() error =", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("ListValid() = %v, want empty", got)
+	}
+	if got, err = ListInvalid(ctx, &badStore{}, lister); err != nil {
+		t.Fatal("ListInvalid() error =", err)
+	}
+	if want := []string{"registry.example.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListInvalid() = %v, want %v", got, want)
+	}
+
+	// listing errors are returned
+	if _, err := ListValid(ctx, ls, NewConfigLister("testdata/invalid_auths_config.json")); err == nil {
+		t.Error("ListValid() error = nil, want error")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := ListInvalid(ctx, ls, lister); !errors.Is(err, context.Canceled) {
+		t.Errorf("ListInvalid() error = %v, wantErr %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrListNotSupported is returned by List() when the store cannot enumerate
+// its server addresses.
+var ErrListNotSupported = errors.New("list not supported")
+
+// Lister enumerates the server addresses having credentials. It is
+// implemented by stores that can reliably list their entries, as an
+// optional complement to the Store interface.
+type Lister interface {
+	// List returns the server addresses having credentials, sorted.
+	List(ctx context.Context) ([]string, error)
+}
+
+// List returns the sorted server addresses having credentials in s, if s
+// implements Lister. Otherwise, ErrListNotSupported is returned.
+func List(ctx context.Context, s Store) ([]string, error) {
+	lister, ok := s.(Lister)
+	if !ok {
+		return nil, ErrListNotSupported
+	}
+	return lister.List(ctx)
+}
+
+// nativeLister lists the server addresses of a credential helper.
+type nativeLister struct {
+	helperName string
+	exe        Executer
+}
+
+// NewNativeLister returns a Lister invoking the "list" action of the
+// credential helper "docker-credential-<helperSuffix>", which is defined by
+// the docker credential helper protocol. As with NewNativeStore, the helper
+// is looked up in $PATH on every call, and the hooks of the ExecutableTrace
+// associated with the context are called. The output of the helper is
+// limited to DefaultMaxResponseBytes.
+//
+// Reference: https://docs.docker.com/engine/reference/commandline/login/#credential-helper-protocol
+func NewNativeLister(helperSuffix string) Lister {
+	return NewNativeListerWithLimit(helperSuffix, DefaultMaxResponseBytes)
+}
+
+// NewNativeListerWithLimit is like [NewNativeLister], with the output of the
+// helper limited to maxOutputBytes. If maxOutputBytes is not positive,
+// DefaultMaxResponseBytes is used.
+func NewNativeListerWithLimit(helperSuffix string, maxOutputBytes int) Lister {
+	return &nativeLister{
+		helperName: "docker-credential-" + helperSuffix,
+		exe:        NewHelperExecuter(helperSuffix, maxOutputBytes),
+	}
+}
+
+// List returns the server addresses having credentials, sorted.
+func (nl *nativeLister) List(ctx context.Context) ([]string, error) {
+	const action = "list"
+	out, err := nl.exe.Execute(ctx, nil, action)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", nl.helperName, action, err)
+	}
+	var list map[string]string
+	if err := json.Unmarshal(out, &list); err != nil {
+		return nil, fmt.Errorf("failed to decode the output of %s %s: %w", nl.helperName, action, err)
+	}
+	serverAddresses := make([]string, 0, len(list))
+	for serverAddress := range list {
+		serverAddresses = append(serverAddresses, serverAddress)
+	}
+	sort.Strings(serverAddresses)
+	return serverAddresses, nil
+}
+
+// configLister lists the server addresses configured in a docker config file.
+type configLister struct {
+	configPath string
+}
+
+// NewConfigLister returns a Lister enumerating the server addresses having
+// credentials according to the docker config file at configPath, the same
+// way a store created by NewStore looks them up:
+//   - the keys of the "auths" field, when no "credsStore" is configured,
+//   - the server addresses listed by the "credsStore" helper otherwise,
+//   - and the keys of the "credHelpers" field.
+//
+// The config file is read on every call. A non-existing config file has no
+// server addresses.
+func NewConfigLister(configPath string) Lister {
+	return &configLister{configPath: configPath}
+}
+
+// List returns the server addresses having credentials, sorted.
+func (cl *configLister) List(ctx context.Context) ([]string, error) {
+	cfg, err := loadDockerConfig(cl.configPath)
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[string]struct{})
+	if cfg.CredentialsStore == "" {
+		for serverAddress := range cfg.AuthConfigs {
+			set[serverAddress] = struct{}{}
+		}
+	} else {
+		list, err := NewNativeLister(cfg.CredentialsStore).List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, serverAddress := range list {
+			set[serverAddress] = struct{}{}
+		}
+	}
+	for serverAddress := range cfg.CredentialHelpers {
+		set[serverAddress] = struct{}{}
+	}
+	serverAddresses := make([]string, 0, len(set))
+	for serverAddress := range set {
+		serverAddresses = append(serverAddresses, serverAddress)
+	}
+	sort.Strings(serverAddresses)
+	return serverAddresses, nil
+}
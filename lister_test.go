@@ -0,0 +1,194 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestList(t *testing.T) {
+	ctx := context.Background()
+	ls := NewLockedMemoryStore()
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	for _, serverAddress := range []string{"registry2.example.com", "registry1.example.com"} {
+		if err := ls.Put(ctx, serverAddress, cred); err != nil {
+			t.Fatal("lockedMemoryStore.Put() error =", err)
+		}
+	}
+	got, err := List(ctx, ls)
+	if err != nil {
+		t.Fatal("List() error =", err)
+	}
+	want := []string{"registry1.example.com", "registry2.example.com"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %v, want %v", got, want)
+	}
+}
+
+func TestList_notSupported(t *testing.T) {
+	if _, err := List(context.Background(), &testStore{}); !errors.Is(err, ErrListNotSupported) {
+		t.Errorf("List() error = %v, wantErr %v", err, ErrListNotSupported)
+	}
+}
+
+func TestNativeLister(t *testing.T) {
+	buildTestHelper(t)
+	ctx := context.Background()
+	ns := NewNativeStore(testHelperSuffix)
+	nl := NewNativeLister(testHelperSuffix)
+
+	got, err := nl.List(ctx)
+	if err != nil {
+		t.Fatal("nativeLister.List() error =", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("nativeLister.List() = %v, want empty", got)
+	}
+
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	for _, serverAddress := range []string{"registry2.example.com", "registry1.example.com"} {
+		if err := ns.Put(ctx, serverAddress, cred); err != nil {
+			t.Fatal("NativeStore.Put() error =", err)
+		}
+	}
+	if got, err = nl.List(ctx); err != nil {
+		t.Fatal("nativeLister.List() error =", err)
+	}
+	want := []string{"registry1.example.com", "registry2.example.com"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("nativeLister.List() = %v, want %v", got, want)
+	}
+}
+
+func TestNativeListerWithLimit(t *testing.T) {
+	buildTestHelper(t)
+	ctx := context.Background()
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := NewNativeStore(testHelperSuffix).Put(ctx, "registry.example.com", cred); err != nil {
+		t.Fatal("NativeStore.Put() error =", err)
+	}
+
+	nl := NewNativeListerWithLimit(testHelperSuffix, 8)
+	if _, err := nl.List(ctx); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("nativeLister.List() error = %v, wantErr %v", err, ErrResponseTooLarge)
+	}
+}
+
+func TestNativeLister_sorted(t *testing.T) {
+	// the helper output is decoded into a map, whose iteration order is
+	// random, so the listing is repeated
+	nl := &nativeLister{
+		helperName: "docker-credential-" + testHelperSuffix,
+		exe: executerFunc(func(context.Context, io.Reader, string) ([]byte, error) {
+			return []byte(`{"registry3.example.com":"u","registry1.example.com:5000":"u","https://index.docker.io/v1/":"u","registry1.example.com":"u","registry2.example.com":"u"}`), nil
+		}),
+	}
+	want := []string{
+		"https://index.docker.io/v1/",
+		"registry1.example.com",
+		"registry1.example.com:5000",
+		"registry2.example.com",
+		"registry3.example.com",
+	}
+	for i := 0; i < 10; i++ {
+		got, err := nl.List(context.Background())
+		if err != nil {
+			t.Fatal("nativeLister.List() error =", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("nativeLister.List() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestNativeLister_helperError(t *testing.T) {
+	buildTestHelper(t)
+	// make the helper fail by pointing its store at a directory
+	t.Setenv("TEST_HELPER_STORE", t.TempDir())
+	if _, err := NewNativeLister(testHelperSuffix).List(context.Background()); err == nil {
+		t.Error("nativeLister.List() error = nil, want error")
+	}
+}
+
+func TestConfigLister(t *testing.T) {
+	cl := NewConfigLister("testdata/credHelpers_config.json")
+	got, err := cl.List(context.Background())
+	if err != nil {
+		t.Fatal("configLister.List() error =", err)
+	}
+	want := []string{"registry1.example.com", "registry2.example.com", "registry3.example.com"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("configLister.List() = %v, want %v", got, want)
+	}
+}
+
+func TestConfigLister_credsStore(t *testing.T) {
+	buildTestHelper(t)
+	ctx := context.Background()
+	if err := NewNativeStore(testHelperSuffix).Put(ctx, "registry.example.com", auth.Credential{
+		RefreshToken: "identity_token",
+	}); err != nil {
+		t.Fatal("NativeStore.Put() error =", err)
+	}
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	config := `{"auths":{"ignored.example.com":{}},"credsStore":"` + testHelperSuffix + `","credHelpers":{"helper.example.com":"whatever"}}`
+	if err := os.WriteFile(configPath, []byte(config), 0666); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	got, err := NewConfigLister(configPath).List(ctx)
+	if err != nil {
+		t.Fatal("configLister.List() error =", err)
+	}
+	want := []string{"helper.example.com", "registry.example.com"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("configLister.List() = %v, want %v", got, want)
+	}
+}
+
+func TestConfigLister_notExistConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "whatever.json")
+	got, err := NewConfigLister(configPath).List(context.Background())
+	if err != nil {
+		t.Fatal("configLister.List() error =", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("configLister.List() = %v, want empty", got)
+	}
+}
+
+func TestConfigLister_badFormat(t *testing.T) {
+	if _, err := NewConfigLister("testdata/invalid_auths_config.json").List(context.Background()); err == nil {
+		t.Error("configLister.List() error = nil, want error")
+	}
+}
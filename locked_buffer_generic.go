@@ -0,0 +1,32 @@
+//go:build !linux && !darwin
+
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+// allocLockedBuffer allocates a buffer of the given size. Memory locking is
+// not supported on this platform, so the buffer is not locked.
+func allocLockedBuffer(size int) ([]byte, error) {
+	return make([]byte, size), nil
+}
+
+// freeLockedBuffer zeroes a buffer allocated by allocLockedBuffer.
+func freeLockedBuffer(buf []byte) error {
+	for i := range buf {
+		buf[i] = 0
+	}
+	return nil
+}
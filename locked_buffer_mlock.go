@@ -0,0 +1,59 @@
+//go:build linux || darwin
+
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// allocLockedBuffer allocates a buffer of the given size outside of the Go
+// heap and locks it into memory. The buffer is mapped separately so that
+// unlocking it never affects the pages of other buffers.
+func allocLockedBuffer(size int) ([]byte, error) {
+	if size == 0 {
+		return nil, nil
+	}
+	buf, err := syscall.Mmap(-1, 0, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_ANON|syscall.MAP_PRIVATE)
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate locked buffer: %w", err)
+	}
+	if err := syscall.Mlock(buf); err != nil {
+		syscall.Munmap(buf)
+		return nil, fmt.Errorf("failed to lock buffer into memory: %w", err)
+	}
+	return buf, nil
+}
+
+// freeLockedBuffer zeroes, unlocks and releases a buffer allocated by
+// allocLockedBuffer.
+func freeLockedBuffer(buf []byte) error {
+	if len(buf) == 0 {
+		return nil
+	}
+	for i := range buf {
+		buf[i] = 0
+	}
+	if err := syscall.Munlock(buf); err != nil {
+		return fmt.Errorf("failed to unlock buffer: %w", err)
+	}
+	if err := syscall.Munmap(buf); err != nil {
+		return fmt.Errorf("failed to release locked buffer: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// lockedMemoryStore is an in-memory store keeping credentials in buffers that
+// are locked into memory.
+type lockedMemoryStore struct {
+	lock    sync.Mutex
+	entries map[string]*lockedCredential
+}
+
+// lockedCredential is a credential serialized into a locked buffer.
+type lockedCredential struct {
+	buf []byte
+	// lens holds the lengths of the Username, Password, RefreshToken and
+	// AccessToken fields, in this order.
+	lens [4]int
+}
+
+// NewLockedMemoryStore creates a new in-memory credentials store whose secrets
+// are kept in buffers locked into memory with mlock(2), so that they are not
+// swapped to disk. Memory locking is supported on Linux and macOS, and is a
+// no-op on other platforms.
+//   - Get() returns copies of the stored credentials.
+//   - Put() returns an error if the buffer cannot be locked, for example when
+//     RLIMIT_MEMLOCK is exceeded.
+//   - Delete() zeroes and unlocks the buffer of the removed credential.
+//
+// The protection only covers the copies held by the store. The credentials
+// passed to Put() and returned by Get() are ordinary strings.
+//
+// The returned store implements Lister and ExistenceChecker.
+func NewLockedMemoryStore() Store {
+	return &lockedMemoryStore{
+		entries: make(map[string]*lockedCredential),
+	}
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (ls *lockedMemoryStore) Get(_ context.Context, serverAddress string) (auth.Credential, error) {
+	ls.lock.Lock()
+	defer ls.lock.Unlock()
+
+	lc, ok := ls.entries[serverAddress]
+	if !ok {
+		return auth.EmptyCredential, nil
+	}
+	var fields [4]string
+	offset := 0
+	for i, n := range lc.lens {
+		fields[i] = string(lc.buf[offset : offset+n])
+		offset += n
+	}
+	return auth.Credential{
+		Username:     fields[0],
+		Password:     fields[1],
+		RefreshToken: fields[2],
+		AccessToken:  fields[3],
+	}, nil
+}
+
+// Put saves credentials into the store for the given server address.
+func (ls *lockedMemoryStore) Put(_ context.Context, serverAddress string, cred auth.Credential) error {
+	fields := [4]string{cred.Username, cred.Password, cred.RefreshToken, cred.AccessToken}
+	lc := &lockedCredential{}
+	size := 0
+	for i, field := range fields {
+		lc.lens[i] = len(field)
+		size += len(field)
+	}
+	buf, err := allocLockedBuffer(size)
+	if err != nil {
+		return err
+	}
+	offset := 0
+	for _, field := range fields {
+		offset += copy(buf[offset:], field)
+	}
+	lc.buf = buf
+
+	ls.lock.Lock()
+	defer ls.lock.Unlock()
+	if old, ok := ls.entries[serverAddress]; ok {
+		if err := freeLockedBuffer(old.buf); err != nil {
+			freeLockedBuffer(buf)
+			return err
+		}
+	}
+	ls.entries[serverAddress] = lc
+	return nil
+}
+
+// Delete removes credentials from the store for the given server address.
+func (ls *lockedMemoryStore) Delete(_ context.Context, serverAddress string) error {
+	ls.lock.Lock()
+	defer ls.lock.Unlock()
+
+	lc, ok := ls.entries[serverAddress]
+	if !ok {
+		return nil
+	}
+	delete(ls.entries, serverAddress)
+	return freeLockedBuffer(lc.buf)
+}
+
+// Exists returns true if non-empty credentials are stored for the given
+// server address, without copying them out of the locked buffer.
+func (ls *lockedMemoryStore) Exists(_ context.Context, serverAddress string) (bool, error) {
+	ls.lock.Lock()
+	defer ls.lock.Unlock()
+
+	lc, ok := ls.entries[serverAddress]
+	return ok && len(lc.buf) > 0, nil
+}
+
+// List returns the server addresses having credentials, sorted.
+func (ls *lockedMemoryStore) List(_ context.Context) ([]string, error) {
+	ls.lock.Lock()
+	defer ls.lock.Unlock()
+
+	serverAddresses := make([]string, 0, len(ls.entries))
+	for serverAddress := range ls.entries {
+		serverAddresses = append(serverAddresses, serverAddress)
+	}
+	sort.Strings(serverAddresses)
+	return serverAddresses, nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestLockedMemoryStore_Get_notExistRecord(t *testing.T) {
+	ctx := context.Background()
+	ls := NewLockedMemoryStore()
+
+	got, err := ls.Get(ctx, "registry.example.com")
+	if err != nil {
+		t.Fatal("lockedMemoryStore.Get() error =", err)
+	}
+	if !reflect.DeepEqual(got, auth.EmptyCredential) {
+		t.Errorf("lockedMemoryStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+}
+
+func TestLockedMemoryStore_Put_update(t *testing.T) {
+	ctx := context.Background()
+	ls := NewLockedMemoryStore()
+	serverAddress := "registry.example.com"
+
+	cred := auth.Credential{
+		Username:     "username",
+		Password:     "password",
+		RefreshToken: "identity_token",
+		AccessToken:  "registry_token",
+	}
+	if err := ls.Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("lockedMemoryStore.Put() error =", err)
+	}
+	got, err := ls.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("lockedMemoryStore.Get() error =", err)
+	}
+	if !reflect.DeepEqual(got, cred) {
+		t.Errorf("lockedMemoryStore.Get() = %v, want %v", got, cred)
+	}
+
+	newCred := auth.Credential{
+		Username: "username2",
+		Password: "password2",
+	}
+	if err := ls.Put(ctx, serverAddress, newCred); err != nil {
+		t.Fatal("lockedMemoryStore.Put() error =", err)
+	}
+	got, err = ls.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("lockedMemoryStore.Get() error =", err)
+	}
+	if !reflect.DeepEqual(got, newCred) {
+		t.Errorf("lockedMemoryStore.Get() = %v, want %v", got, newCred)
+	}
+}
+
+func TestLockedMemoryStore_Put_emptyCredential(t *testing.T) {
+	ctx := context.Background()
+	ls := NewLockedMemoryStore()
+	serverAddress := "registry.example.com"
+
+	if err := ls.Put(ctx, serverAddress, auth.EmptyCredential); err != nil {
+		t.Fatal("lockedMemoryStore.Put() error =", err)
+	}
+	got, err := ls.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("lockedMemoryStore.Get() error =", err)
+	}
+	if !reflect.DeepEqual(got, auth.EmptyCredential) {
+		t.Errorf("lockedMemoryStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+	if err := ls.Delete(ctx, serverAddress); err != nil {
+		t.Fatal("lockedMemoryStore.Delete() error =", err)
+	}
+}
+
+func TestLockedMemoryStore_Delete(t *testing.T) {
+	ctx := context.Background()
+	ls := NewLockedMemoryStore()
+	serverAddress := "registry.example.com"
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := ls.Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("lockedMemoryStore.Put() error =", err)
+	}
+
+	if err := ls.Delete(ctx, serverAddress); err != nil {
+		t.Fatal("lockedMemoryStore.Delete() error =", err)
+	}
+	got, err := ls.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("lockedMemoryStore.Get() error =", err)
+	}
+	if !reflect.DeepEqual(got, auth.EmptyCredential) {
+		t.Errorf("lockedMemoryStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+
+	// deleting a non-existing record should not fail
+	if err := ls.Delete(ctx, serverAddress); err != nil {
+		t.Error("lockedMemoryStore.Delete() error =", err)
+	}
+}
+
+func Test_lockedBuffer(t *testing.T) {
+	buf, err := allocLockedBuffer(16)
+	if err != nil {
+		t.Fatal("allocLockedBuffer() error =", err)
+	}
+	if len(buf) != 16 {
+		t.Errorf("len(allocLockedBuffer()) = %d, want 16", len(buf))
+	}
+	copy(buf, "secret")
+	if err := freeLockedBuffer(buf); err != nil {
+		t.Error("freeLockedBuffer() error =", err)
+	}
+}
@@ -16,14 +16,57 @@ limitations under the License.
 package credentials
 
 import (
-	"oras.land/oras-go/v2/registry/remote/credentials"
+	"context"
+	"sort"
+	"sync"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
 )
 
-// NewMemoryStore creates a new in-memory credentials store.
+// memoryStore is a store that keeps credentials in memory.
+type memoryStore struct {
+	store sync.Map
+}
+
+// NewMemoryStore creates a new in-memory credentials store. The returned
+// store implements [Lister], so it can be snapshotted by [SaveTo].
 //
-// Deprecated: This funciton now simply calls [credentials.NewMemoryStore] of oras-go.
+// Deprecated: Apart from implementing [Lister], this funciton now behaves as
+// [credentials.NewMemoryStore] of oras-go.
 //
 // [credentials.NewMemoryStore]: https://pkg.go.dev/oras.land/oras-go/v2/registry/remote/credentials#NewMemoryStore
 func NewMemoryStore() Store {
-	return credentials.NewMemoryStore()
+	return &memoryStore{}
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (ms *memoryStore) Get(_ context.Context, serverAddress string) (auth.Credential, error) {
+	cred, found := ms.store.Load(serverAddress)
+	if !found {
+		return auth.EmptyCredential, nil
+	}
+	return cred.(auth.Credential), nil
+}
+
+// Put saves credentials into the store for the given server address.
+func (ms *memoryStore) Put(_ context.Context, serverAddress string, cred auth.Credential) error {
+	ms.store.Store(serverAddress, cred)
+	return nil
+}
+
+// Delete removes credentials from the store for the given server address.
+func (ms *memoryStore) Delete(_ context.Context, serverAddress string) error {
+	ms.store.Delete(serverAddress)
+	return nil
+}
+
+// List returns the server addresses having credentials, sorted.
+func (ms *memoryStore) List(_ context.Context) ([]string, error) {
+	var serverAddresses []string
+	ms.store.Range(func(key, _ interface{}) bool {
+		serverAddresses = append(serverAddresses, key.(string))
+		return true
+	})
+	sort.Strings(serverAddresses)
+	return serverAddresses, nil
 }
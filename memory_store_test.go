@@ -227,3 +227,29 @@ func TestMemoryStore_Delete_notExistRecord(t *testing.T) {
 		return
 	}
 }
+
+func TestMemoryStore_List(t *testing.T) {
+	ctx := context.Background()
+	ms := NewMemoryStore()
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	for _, serverAddress := range []string{"registry2.example.com", "registry1.example.com", "registry3.example.com"} {
+		if err := ms.Put(ctx, serverAddress, cred); err != nil {
+			t.Fatal("MemoryStore.Put() error =", err)
+		}
+	}
+	if err := ms.Delete(ctx, "registry3.example.com"); err != nil {
+		t.Fatal("MemoryStore.Delete() error =", err)
+	}
+
+	got, err := List(ctx, ms)
+	if err != nil {
+		t.Fatal("List() error =", err)
+	}
+	want := []string{"registry1.example.com", "registry2.example.com"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %v, want %v", got, want)
+	}
+}
@@ -25,9 +25,15 @@ import (
 // The argument of NewNativeStore can be the native keychains
 // ("wincred" for Windows, "pass" for linux and "osxkeychain" for macOS),
 // or any program that follows the docker-credentials-helper protocol.
+// The program is looked up in $PATH as "docker-credential-<helperSuffix>" on
+// every call, so a helper installed after the store is created is picked up
+// without reconstructing the store. It is only interacted with through the
+// "store", "get" and "erase" actions over stdin and stdout, so custom helpers,
+// such as hardware-backed ones, work as long as they honor the protocol.
 //
 // Reference:
 //   - https://docs.docker.com/engine/reference/commandline/login#credentials-store
+//   - https://docs.docker.com/engine/reference/commandline/login/#credential-helper-protocol
 //
 // Deprecated: This funciton now simply calls [credentials.NewNativeStore] of oras-go.
 //
@@ -52,3 +58,19 @@ func NewNativeStore(helperSuffix string) Store {
 func NewDefaultNativeStore() (Store, bool) {
 	return credentials.NewDefaultNativeStore()
 }
+
+// NewNativeStoreWithFileFallback returns a store for migrating credentials from
+// the docker config file at configPath to the native store of the given
+// helperSuffix.
+//
+// Get() looks up the native store first, and falls back to the plaintext
+// credentials in the config file. Put() and Delete() only apply to the native
+// store, so new credentials are never saved in plaintext, and legacy
+// credentials remain in the config file until they are removed from it.
+func NewNativeStoreWithFileFallback(helperSuffix, configPath string) (Store, error) {
+	fs, err := NewFileStore(configPath)
+	if err != nil {
+		return nil, err
+	}
+	return NewStoreWithFallbacks(NewNativeStore(helperSuffix), fs), nil
+}
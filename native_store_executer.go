@@ -0,0 +1,333 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/credentials/trace"
+)
+
+// errCredentialsNotFoundMessage is the error message reported by credential
+// helpers having no credential for a server URL.
+const errCredentialsNotFoundMessage = "credentials not found in native keychain"
+
+// dockerDesktopHelperName is the name of the credential helper of Docker
+// Desktop on WSL, which is only available while Docker Desktop is running.
+const dockerDesktopHelperName = "docker-credential-desktop.exe"
+
+// DefaultMaxResponseBytes is the default limit of the size of the responses
+// read from credential helpers and credential services, protecting against
+// runaway or malicious ones.
+const DefaultMaxResponseBytes = 4 * 1024 * 1024 // 4 MiB
+
+// ErrResponseTooLarge is returned when a credential helper or a credential
+// service responds with more bytes than allowed.
+var ErrResponseTooLarge = errors.New("response too large")
+
+// Executer executes an action of a credential helper, defined by the docker
+// credential helper protocol, with the given input, and returns its output.
+// On failure, the returned error should carry the message reported by the
+// helper, such as "credentials not found in native keychain".
+//
+// As the output is read by the Executer, implementations are responsible for
+// limiting its size, as the one returned by NewHelperExecuter does.
+//
+// Reference: https://docs.docker.com/engine/reference/commandline/login/#credential-helper-protocol
+type Executer interface {
+	Execute(ctx context.Context, input io.Reader, action string) ([]byte, error)
+}
+
+// executerService is a RemoteCredentialService over an Executer.
+type executerService struct {
+	exe Executer
+}
+
+// NewNativeStoreWithExecuter returns a native store like the one returned by
+// [NewNativeStore], whose helper actions are run by exe instead of executing
+// a helper binary. It allows unit testing code wiring up a native store, and
+// wrapping helper invocations with timeouts, auditing or sandboxing.
+//
+// Unlike oras-go, a leading UTF-8 byte order mark in the output of the "get"
+// action is ignored, to interoperate with helpers emitting one.
+func NewNativeStoreWithExecuter(exe Executer) Store {
+	return NewRemoteStore(&executerService{exe: exe})
+}
+
+// Get retrieves the credential for serverURL.
+func (es *executerService) Get(ctx context.Context, serverURL string) (RemoteCredential, error) {
+	out, err := es.exe.Execute(ctx, strings.NewReader(serverURL), "get")
+	if err != nil {
+		if err.Error() == errCredentialsNotFoundMessage {
+			return RemoteCredential{}, fmt.Errorf("%w: %s", ErrCredentialNotFound, serverURL)
+		}
+		return RemoteCredential{}, err
+	}
+	var cred RemoteCredential
+	if err := json.Unmarshal(trimHelperOutput(out), &cred); err != nil {
+		return RemoteCredential{}, err
+	}
+	return cred, nil
+}
+
+// utf8BOM is the byte order mark that some helpers prefix their output with.
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// trimHelperOutput strips a leading UTF-8 byte order mark and the surrounding
+// whitespace from the output of a helper, which encoding/json rejects.
+func trimHelperOutput(out []byte) []byte {
+	return bytes.TrimSpace(bytes.TrimPrefix(bytes.TrimSpace(out), utf8BOM))
+}
+
+// Store saves cred for cred.ServerURL.
+func (es *executerService) Store(ctx context.Context, cred RemoteCredential) error {
+	credJSON, err := json.Marshal(cred)
+	if err != nil {
+		return err
+	}
+	_, err = es.exe.Execute(ctx, bytes.NewReader(credJSON), "store")
+	return err
+}
+
+// Erase removes the credential for serverURL.
+func (es *executerService) Erase(ctx context.Context, serverURL string) error {
+	_, err := es.exe.Execute(ctx, strings.NewReader(serverURL), "erase")
+	return err
+}
+
+// helperExecuter is an Executer running a credential helper binary.
+type helperExecuter struct {
+	helperName string
+	opts       HelperExecuterOptions
+}
+
+// HelperExecuterOptions provides options for NewHelperExecuterWithOptions.
+type HelperExecuterOptions struct {
+	// MaxOutputBytes limits the size of the output of the helper. Reading
+	// the output stops with ErrResponseTooLarge as soon as it exceeds the
+	// limit. If MaxOutputBytes is not positive, DefaultMaxResponseBytes is
+	// used.
+	MaxOutputBytes int
+
+	// ParseStderrFallback works around misbehaving helpers printing their
+	// response to stderr instead of stdout. When set, the stderr of the
+	// helper is captured, in addition to being forwarded to os.Stderr, and
+	// returned as the output if the helper succeeds with an empty stdout and
+	// a stderr holding valid JSON.
+	ParseStderrFallback bool
+}
+
+// NewHelperExecuter returns an Executer running the credential helper
+// "docker-credential-<helperSuffix>", which is looked up in $PATH on every
+// call. Like the helpers run by NewNativeStore, the stderr of the helper is
+// forwarded to os.Stderr, and the hooks of the ExecutableTrace associated
+// with the context are called. Reading the output of the helper stops with
+// ErrResponseTooLarge as soon as it exceeds maxOutputBytes. If maxOutputBytes
+// is not positive, DefaultMaxResponseBytes is used.
+//
+// The native stores created by NewNativeStore and NewStore read the output of
+// helpers in oras-go, without a limit. For a native store with limited helper
+// output, use NewNativeStoreWithExecuter(NewHelperExecuter(helperSuffix, n)).
+func NewHelperExecuter(helperSuffix string, maxOutputBytes int) Executer {
+	return NewHelperExecuterWithOptions(helperSuffix, HelperExecuterOptions{
+		MaxOutputBytes: maxOutputBytes,
+	})
+}
+
+// NewHelperExecuterWithOptions is like [NewHelperExecuter], with the given
+// options.
+func NewHelperExecuterWithOptions(helperSuffix string, opts HelperExecuterOptions) Executer {
+	if opts.MaxOutputBytes <= 0 {
+		opts.MaxOutputBytes = DefaultMaxResponseBytes
+	}
+	return &helperExecuter{
+		helperName: "docker-credential-" + helperSuffix,
+		opts:       opts,
+	}
+}
+
+// Execute runs the helper with the given action, feeding input to its stdin,
+// and returns its stdout.
+func (he *helperExecuter) Execute(ctx context.Context, input io.Reader, action string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, he.helperName, action)
+	cmd.Stdin = input
+	cmd.Stderr = os.Stderr
+	var stderr *capturingWriter
+	if he.opts.ParseStderrFallback {
+		stderr = &capturingWriter{
+			forward: os.Stderr,
+			buf:     limitedBuffer{limit: he.opts.MaxOutputBytes},
+		}
+		cmd.Stderr = stderr
+	}
+	stdout := &limitedBuffer{limit: he.opts.MaxOutputBytes}
+	cmd.Stdout = stdout
+	if et := trace.ContextExecutableTrace(ctx); et != nil && et.ExecuteStart != nil {
+		et.ExecuteStart(he.helperName, action)
+	}
+	err := cmd.Run()
+	if et := trace.ContextExecutableTrace(ctx); et != nil && et.ExecuteDone != nil {
+		et.ExecuteDone(he.helperName, action, err)
+	}
+	if stdout.exceeded {
+		return nil, fmt.Errorf("%w: the output of %s %s exceeds %d bytes", ErrResponseTooLarge, he.helperName, action, stdout.limit)
+	}
+	if err != nil {
+		switch execErr := err.(type) {
+		case *exec.ExitError:
+			if msg := strings.TrimSpace(stdout.String()); msg != "" {
+				// the helper reports the error on stdout
+				return nil, errors.New(msg)
+			}
+		case *exec.Error:
+			// check if the error is caused by Docker Desktop not running
+			if execErr.Err == exec.ErrNotFound && he.helperName == dockerDesktopHelperName {
+				return nil, errors.New("credentials store is configured to `desktop.exe` but Docker Desktop seems not running")
+			}
+		}
+		return nil, err
+	}
+	if stderr != nil && len(bytes.TrimSpace(stdout.Bytes())) == 0 && !stderr.buf.exceeded {
+		if out := bytes.TrimSpace(stderr.buf.Bytes()); json.Valid(out) {
+			return out, nil
+		}
+	}
+	return stdout.Bytes(), nil
+}
+
+// capturingWriter is a writer forwarding the written bytes, and capturing
+// them up to the limit of its buffer.
+type capturingWriter struct {
+	forward io.Writer
+	buf     limitedBuffer
+}
+
+// Write forwards p and captures it if the limit of the buffer is not
+// exceeded. Exceeding the limit does not stop the forwarding.
+func (cw *capturingWriter) Write(p []byte) (int, error) {
+	cw.buf.Write(p)
+	return cw.forward.Write(p)
+}
+
+// retryExecuter is an Executer retrying the failed actions of an inner
+// Executer.
+type retryExecuter struct {
+	inner       Executer
+	maxAttempts int
+	backoff     time.Duration
+}
+
+// NewRetryExecuter returns an Executer running the actions with inner, which
+// retries an action failing with a retryable error, such as a transient
+// network failure of a cloud credential helper. The action is attempted at
+// most maxAttempts times, waiting backoff before the second attempt and
+// doubling the wait before each further attempt. If maxAttempts is less than
+// 1, the action is attempted once.
+//
+// Errors reporting missing credentials, a missing helper, a response too
+// large or a done context are not retryable. The input is read before the
+// first attempt so that it can be replayed.
+//
+// For a native store retrying helper actions, pass a retrying Executer
+// wrapping the one returned by NewHelperExecuter to
+// NewNativeStoreWithExecuter.
+func NewRetryExecuter(inner Executer, maxAttempts int, backoff time.Duration) Executer {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return &retryExecuter{
+		inner:       inner,
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+	}
+}
+
+// Execute runs the action with the inner Executer, retrying it on retryable
+// errors.
+func (re *retryExecuter) Execute(ctx context.Context, input io.Reader, action string) ([]byte, error) {
+	in, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	wait := re.backoff
+	for attempt := 1; ; attempt++ {
+		out, err := re.inner.Execute(ctx, bytes.NewReader(in), action)
+		if err == nil || attempt >= re.maxAttempts || !isRetryable(err) {
+			return out, err
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		wait *= 2
+	}
+}
+
+// isRetryable reports whether an action failing with err may succeed if
+// attempted again.
+func isRetryable(err error) bool {
+	switch {
+	case err.Error() == errCredentialsNotFoundMessage,
+		errors.Is(err, exec.ErrNotFound),
+		errors.Is(err, ErrResponseTooLarge),
+		errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded):
+		return false
+	default:
+		return true
+	}
+}
+
+// limitedBuffer is a buffer refusing writes beyond a limit. It does not embed
+// bytes.Buffer, whose ReadFrom method would let io.Copy bypass the limit.
+type limitedBuffer struct {
+	buf      bytes.Buffer
+	limit    int
+	exceeded bool
+}
+
+// Write appends p to the buffer. If the limit would be exceeded, nothing is
+// written and ErrResponseTooLarge is returned, which stops the copy from the
+// process output.
+func (lb *limitedBuffer) Write(p []byte) (int, error) {
+	if lb.exceeded || len(p) > lb.limit-lb.buf.Len() {
+		lb.exceeded = true
+		return 0, ErrResponseTooLarge
+	}
+	return lb.buf.Write(p)
+}
+
+// Bytes returns the content of the buffer.
+func (lb *limitedBuffer) Bytes() []byte {
+	return lb.buf.Bytes()
+}
+
+// String returns the content of the buffer as a string.
+func (lb *limitedBuffer) String() string {
+	return lb.buf.String()
+}
@@ -0,0 +1,386 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// testExecuter implements the Executer interface for testing purpose.
+// It simulates interactions between the docker client and a remote
+// credentials helper.
+type testExecuter struct {
+	creds   map[string]RemoteCredential
+	actions []string
+}
+
+// Execute mocks the behavior of a credential helper binary. It returns
+// responses and errors based on the input.
+func (e *testExecuter) Execute(ctx context.Context, input io.Reader, action string) ([]byte, error) {
+	e.actions = append(e.actions, action)
+	in, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	switch action {
+	case "get":
+		cred, ok := e.creds[string(in)]
+		if !ok {
+			return nil, errors.New(errCredentialsNotFoundMessage)
+		}
+		return json.Marshal(cred)
+	case "store":
+		var cred RemoteCredential
+		if err := json.Unmarshal(in, &cred); err != nil {
+			return nil, err
+		}
+		e.creds[cred.ServerURL] = cred
+		return nil, nil
+	case "erase":
+		delete(e.creds, string(in))
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown action: %s", action)
+	}
+}
+
+func TestNativeStoreWithExecuter(t *testing.T) {
+	exe := &testExecuter{creds: make(map[string]RemoteCredential)}
+	ns := NewNativeStoreWithExecuter(exe)
+	ctx := context.Background()
+	serverAddress := "registry.example.com"
+
+	// test get non-existing credential
+	got, err := ns.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("NativeStore.Get() error =", err)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("NativeStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+
+	// test put and get refresh token
+	cred := auth.Credential{
+		RefreshToken: "identity_token",
+	}
+	if err := ns.Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("NativeStore.Put() error =", err)
+	}
+	wantStored := RemoteCredential{
+		ServerURL: serverAddress,
+		Username:  "<token>",
+		Secret:    "identity_token",
+	}
+	if exe.creds[serverAddress] != wantStored {
+		t.Errorf("stored credential = %v, want %v", exe.creds[serverAddress], wantStored)
+	}
+	if got, err = ns.Get(ctx, serverAddress); err != nil {
+		t.Fatal("NativeStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("NativeStore.Get() = %v, want %v", got, cred)
+	}
+
+	// test delete
+	if err := ns.Delete(ctx, serverAddress); err != nil {
+		t.Fatal("NativeStore.Delete() error =", err)
+	}
+	if _, ok := exe.creds[serverAddress]; ok {
+		t.Errorf("credential for %s not erased", serverAddress)
+	}
+
+	wantActions := []string{"get", "store", "get", "erase"}
+	if !reflect.DeepEqual(exe.actions, wantActions) {
+		t.Errorf("executed actions = %v, want %v", exe.actions, wantActions)
+	}
+}
+
+func TestNativeStoreWithExecuter_executerError(t *testing.T) {
+	ctx := context.Background()
+	errExec := errors.New("helper crashed")
+	ns := NewNativeStoreWithExecuter(executerFunc(func(context.Context, io.Reader, string) ([]byte, error) {
+		return nil, errExec
+	}))
+	if _, err := ns.Get(ctx, "registry.example.com"); !errors.Is(err, errExec) {
+		t.Errorf("NativeStore.Get() error = %v, wantErr %v", err, errExec)
+	}
+	if err := ns.Put(ctx, "registry.example.com", auth.EmptyCredential); !errors.Is(err, errExec) {
+		t.Errorf("NativeStore.Put() error = %v, wantErr %v", err, errExec)
+	}
+	if err := ns.Delete(ctx, "registry.example.com"); !errors.Is(err, errExec) {
+		t.Errorf("NativeStore.Delete() error = %v, wantErr %v", err, errExec)
+	}
+}
+
+func TestNativeStoreWithExecuter_bomPrefixedOutput(t *testing.T) {
+	ns := NewNativeStoreWithExecuter(executerFunc(func(context.Context, io.Reader, string) ([]byte, error) {
+		return []byte("\xef\xbb\xbf {\"ServerURL\":\"registry.example.com\",\"Username\":\"username\",\"Secret\":\"password\"}\n"), nil
+	}))
+	got, err := ns.Get(context.Background(), "registry.example.com")
+	if err != nil {
+		t.Fatal("NativeStore.Get() error =", err)
+	}
+	want := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if got != want {
+		t.Errorf("NativeStore.Get() = %v, want %v", got, want)
+	}
+}
+
+func TestNativeStoreWithExecuter_helperExecuter(t *testing.T) {
+	buildTestHelper(t)
+	ns := NewNativeStoreWithExecuter(NewHelperExecuter(testHelperSuffix, 0))
+	ctx := context.Background()
+	serverAddress := "registry.example.com"
+
+	// test get non-existing credential
+	got, err := ns.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("NativeStore.Get() error =", err)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("NativeStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+
+	// test put and get basic credential
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := ns.Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("NativeStore.Put() error =", err)
+	}
+	if got, err = ns.Get(ctx, serverAddress); err != nil {
+		t.Fatal("NativeStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("NativeStore.Get() = %v, want %v", got, cred)
+	}
+
+	// test delete
+	if err := ns.Delete(ctx, serverAddress); err != nil {
+		t.Fatal("NativeStore.Delete() error =", err)
+	}
+	if got, err = ns.Get(ctx, serverAddress); err != nil {
+		t.Fatal("NativeStore.Get() error =", err)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("NativeStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+}
+
+func TestHelperExecuter_outputTooLarge(t *testing.T) {
+	buildTestHelper(t)
+	ctx := context.Background()
+	serverAddress := "registry.example.com"
+	cred := auth.Credential{
+		Username: "username",
+		Password: strings.Repeat("p", 128),
+	}
+	if err := NewNativeStore(testHelperSuffix).Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("NativeStore.Put() error =", err)
+	}
+
+	ns := NewNativeStoreWithExecuter(NewHelperExecuter(testHelperSuffix, 64))
+	if _, err := ns.Get(ctx, serverAddress); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("NativeStore.Get() error = %v, wantErr %v", err, ErrResponseTooLarge)
+	}
+}
+
+func TestHelperExecuterWithOptions_ParseStderrFallback(t *testing.T) {
+	buildTestHelper(t)
+	ctx := context.Background()
+	serverAddress := "registry.example.com"
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := NewNativeStore(testHelperSuffix).Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("NativeStore.Put() error =", err)
+	}
+	// make the helper respond on stderr, and silence the forwarded stderr
+	t.Setenv("TEST_HELPER_STDERR", "1")
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", os.DevNull, err)
+	}
+	defer devNull.Close()
+	stderr := os.Stderr
+	os.Stderr = devNull
+	defer func() { os.Stderr = stderr }()
+
+	// without the fallback, the empty stdout cannot be decoded
+	ns := NewNativeStoreWithExecuter(NewHelperExecuter(testHelperSuffix, 0))
+	if _, err := ns.Get(ctx, serverAddress); err == nil {
+		t.Error("NativeStore.Get() error = nil, want error")
+	}
+
+	// with the fallback, the response is read from stderr
+	ns = NewNativeStoreWithExecuter(NewHelperExecuterWithOptions(testHelperSuffix, HelperExecuterOptions{
+		ParseStderrFallback: true,
+	}))
+	got, err := ns.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("NativeStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("NativeStore.Get() = %v, want %v", got, cred)
+	}
+}
+
+func TestCapturingWriter(t *testing.T) {
+	var forwarded strings.Builder
+	cw := &capturingWriter{
+		forward: &forwarded,
+		buf:     limitedBuffer{limit: 4},
+	}
+	for _, p := range []string{"1234", "5678"} {
+		if n, err := cw.Write([]byte(p)); err != nil || n != len(p) {
+			t.Fatalf("capturingWriter.Write() = %d, %v, want %d, nil", n, err, len(p))
+		}
+	}
+	if got, want := forwarded.String(), "12345678"; got != want {
+		t.Errorf("forwarded = %s, want %s", got, want)
+	}
+	if got, want := cw.buf.String(), "1234"; got != want || !cw.buf.exceeded {
+		t.Errorf("captured = %s, exceeded %v, want %s, true", got, cw.buf.exceeded, want)
+	}
+}
+
+func TestHelperExecuter_dockerDesktopNotRunning(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	_, err := NewHelperExecuter("desktop.exe", 0).Execute(context.Background(), strings.NewReader(""), "get")
+	if want := "credentials store is configured to `desktop.exe` but Docker Desktop seems not running"; err == nil || err.Error() != want {
+		t.Errorf("helperExecuter.Execute() error = %v, want %v", err, want)
+	}
+}
+
+func TestRetryExecuter(t *testing.T) {
+	ctx := context.Background()
+	errTransient := errors.New("connection reset by peer")
+	var attempts int
+	var inputs []string
+	inner := executerFunc(func(_ context.Context, input io.Reader, _ string) ([]byte, error) {
+		attempts++
+		b, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, string(b))
+		if attempts < 3 {
+			return nil, errTransient
+		}
+		return []byte("output"), nil
+	})
+
+	// test succeeding after transient failures
+	re := NewRetryExecuter(inner, 3, time.Millisecond)
+	got, err := re.Execute(ctx, strings.NewReader("registry.example.com"), "get")
+	if err != nil {
+		t.Fatal("retryExecuter.Execute() error =", err)
+	}
+	if string(got) != "output" {
+		t.Errorf("retryExecuter.Execute() = %s, want %s", got, "output")
+	}
+	want := []string{"registry.example.com", "registry.example.com", "registry.example.com"}
+	if !reflect.DeepEqual(inputs, want) {
+		t.Errorf("inner executer inputs = %v, want %v", inputs, want)
+	}
+
+	// test giving up after maxAttempts
+	attempts = 0
+	re = NewRetryExecuter(inner, 2, time.Millisecond)
+	if _, err := re.Execute(ctx, strings.NewReader("registry.example.com"), "get"); !errors.Is(err, errTransient) {
+		t.Errorf("retryExecuter.Execute() error = %v, wantErr %v", err, errTransient)
+	}
+	if attempts != 2 {
+		t.Errorf("inner executer called %d times, want 2", attempts)
+	}
+}
+
+func TestRetryExecuter_notRetryable(t *testing.T) {
+	ctx := context.Background()
+	for _, wantErr := range []error{
+		errors.New(errCredentialsNotFoundMessage),
+		ErrResponseTooLarge,
+		exec.ErrNotFound,
+		context.DeadlineExceeded,
+	} {
+		var attempts int
+		re := NewRetryExecuter(executerFunc(func(context.Context, io.Reader, string) ([]byte, error) {
+			attempts++
+			return nil, wantErr
+		}), 3, time.Millisecond)
+		if _, err := re.Execute(ctx, strings.NewReader(""), "get"); err != wantErr {
+			t.Errorf("retryExecuter.Execute() error = %v, wantErr %v", err, wantErr)
+		}
+		if attempts != 1 {
+			t.Errorf("inner executer called %d times for %v, want 1", attempts, wantErr)
+		}
+	}
+}
+
+func TestRetryExecuter_contextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	re := NewRetryExecuter(executerFunc(func(context.Context, io.Reader, string) ([]byte, error) {
+		cancel()
+		return nil, errors.New("connection reset by peer")
+	}), 3, time.Hour)
+	if _, err := re.Execute(ctx, strings.NewReader(""), "get"); !errors.Is(err, context.Canceled) {
+		t.Errorf("retryExecuter.Execute() error = %v, wantErr %v", err, context.Canceled)
+	}
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	lb := &limitedBuffer{limit: 8}
+	if n, err := lb.Write([]byte("1234")); err != nil || n != 4 {
+		t.Fatalf("limitedBuffer.Write() = %d, %v, want 4, nil", n, err)
+	}
+	if n, err := lb.Write([]byte("5678")); err != nil || n != 4 {
+		t.Fatalf("limitedBuffer.Write() = %d, %v, want 4, nil", n, err)
+	}
+	if _, err := lb.Write([]byte("9")); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("limitedBuffer.Write() error = %v, wantErr %v", err, ErrResponseTooLarge)
+	}
+	if !lb.exceeded {
+		t.Error("limitedBuffer.exceeded = false, want true")
+	}
+	if got, want := lb.String(), "12345678"; got != want {
+		t.Errorf("limitedBuffer.String() = %v, want %v", got, want)
+	}
+}
+
+// executerFunc adapts a function to the Executer interface, used for testing
+// purpose.
+type executerFunc func(ctx context.Context, input io.Reader, action string) ([]byte, error)
+
+func (f executerFunc) Execute(ctx context.Context, input io.Reader, action string) ([]byte, error) {
+	return f(ctx, input, action)
+}
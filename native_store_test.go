@@ -0,0 +1,264 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/oras-project/oras-credentials-go/internal/config/configtest"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// testHelperSuffix is the suffix of the credential helper built from
+// testdata/credential-helper.
+const testHelperSuffix = "test"
+
+// buildTestHelper builds the credential helper in testdata/credential-helper
+// as docker-credential-test, puts it in $PATH and returns its path.
+func buildTestHelper(t *testing.T) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping building the test credential helper in short mode")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("skipping as the go command is not available:", err)
+	}
+
+	binDir := t.TempDir()
+	helperPath := filepath.Join(binDir, "docker-credential-"+testHelperSuffix)
+	if runtime.GOOS == "windows" {
+		helperPath += ".exe"
+	}
+	cmd := exec.Command(goBin, "build", "-o", helperPath, "./testdata/credential-helper")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("failed to build the test credential helper: %v\n%s", err, out)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("TEST_HELPER_STORE", filepath.Join(t.TempDir(), "store.json"))
+	return helperPath
+}
+
+// listTestHelper invokes the "list" action of the test credential helper.
+func listTestHelper(t *testing.T, helperPath string) map[string]string {
+	t.Helper()
+	out, err := exec.Command(helperPath, "list").Output()
+	if err != nil {
+		t.Fatalf("failed to list credentials: %v", err)
+	}
+	var list map[string]string
+	if err := json.Unmarshal(out, &list); err != nil {
+		t.Fatalf("failed to decode the list output: %v", err)
+	}
+	return list
+}
+
+func TestNativeStore_credentialHelperProtocol(t *testing.T) {
+	helperPath := buildTestHelper(t)
+	ctx := context.Background()
+	ns := NewNativeStore(testHelperSuffix)
+
+	// test get non-existing credential
+	basicServer := "basic.example.com"
+	got, err := ns.Get(ctx, basicServer)
+	if err != nil {
+		t.Fatal("NativeStore.Get() error =", err)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("NativeStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+
+	// test store and get basic credential
+	basicCred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := ns.Put(ctx, basicServer, basicCred); err != nil {
+		t.Fatal("NativeStore.Put() error =", err)
+	}
+	if got, err = ns.Get(ctx, basicServer); err != nil {
+		t.Fatal("NativeStore.Get() error =", err)
+	}
+	if got != basicCred {
+		t.Errorf("NativeStore.Get() = %v, want %v", got, basicCred)
+	}
+
+	// test store and get refresh token, which is stored with the "<token>"
+	// username
+	tokenServer := "token.example.com"
+	tokenCred := auth.Credential{
+		RefreshToken: "identity_token",
+	}
+	if err := ns.Put(ctx, tokenServer, tokenCred); err != nil {
+		t.Fatal("NativeStore.Put() error =", err)
+	}
+	if got, err = ns.Get(ctx, tokenServer); err != nil {
+		t.Fatal("NativeStore.Get() error =", err)
+	}
+	if got != tokenCred {
+		t.Errorf("NativeStore.Get() = %v, want %v", got, tokenCred)
+	}
+
+	// the helper should have received the credentials as defined by the
+	// protocol
+	wantList := map[string]string{
+		basicServer: "username",
+		tokenServer: "<token>",
+	}
+	if got := listTestHelper(t, helperPath); !reflect.DeepEqual(got, wantList) {
+		t.Errorf("list = %v, want %v", got, wantList)
+	}
+
+	// test erase
+	if err := ns.Delete(ctx, basicServer); err != nil {
+		t.Fatal("NativeStore.Delete() error =", err)
+	}
+	if got, err = ns.Get(ctx, basicServer); err != nil {
+		t.Fatal("NativeStore.Get() error =", err)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("NativeStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+	wantList = map[string]string{
+		tokenServer: "<token>",
+	}
+	if got := listTestHelper(t, helperPath); !reflect.DeepEqual(got, wantList) {
+		t.Errorf("list = %v, want %v", got, wantList)
+	}
+}
+
+func TestNativeStore_helperError(t *testing.T) {
+	buildTestHelper(t)
+	// make the helper fail by pointing its store at a directory
+	t.Setenv("TEST_HELPER_STORE", t.TempDir())
+	ctx := context.Background()
+	ns := NewNativeStore(testHelperSuffix)
+
+	_, err := ns.Get(ctx, "registry.example.com")
+	if err == nil {
+		t.Fatal("NativeStore.Get() error = nil, want error")
+	}
+	// the error message printed by the helper should be surfaced
+	if strings.Contains(err.Error(), "exit status") {
+		t.Errorf("NativeStore.Get() error = %v, want the helper message", err)
+	}
+}
+
+func TestNativeStore_helperInstalledAfterCreation(t *testing.T) {
+	origPath := os.Getenv("PATH")
+	buildTestHelper(t)
+	installedPath := os.Getenv("PATH")
+	ctx := context.Background()
+
+	// the store is created before the helper is installed
+	t.Setenv("PATH", origPath)
+	ns := NewNativeStore(testHelperSuffix)
+	serverAddress := "registry.example.com"
+	if _, err := ns.Get(ctx, serverAddress); err == nil {
+		t.Fatal("NativeStore.Get() error = nil, want error for missing helper")
+	}
+
+	// the helper is looked up in $PATH on every call, so no refresh is needed
+	t.Setenv("PATH", installedPath)
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := ns.Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("NativeStore.Put() error =", err)
+	}
+	got, err := ns.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("NativeStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("NativeStore.Get() = %v, want %v", got, cred)
+	}
+}
+
+func TestNewNativeStoreWithFileFallback(t *testing.T) {
+	helperPath := buildTestHelper(t)
+	ctx := context.Background()
+
+	// prepare a config file with a legacy plaintext credential
+	legacyServer := "legacy.example.com"
+	legacyCred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	cfg := configtest.Config{
+		AuthConfigs: map[string]configtest.AuthConfig{
+			legacyServer: {
+				Auth: "dXNlcm5hbWU6cGFzc3dvcmQ=",
+			},
+		},
+	}
+	jsonStr, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("failed to marshal config: %v", err)
+	}
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, jsonStr, 0666); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	s, err := NewNativeStoreWithFileFallback(testHelperSuffix, configPath)
+	if err != nil {
+		t.Fatal("NewNativeStoreWithFileFallback() error =", err)
+	}
+
+	// test reading the legacy credential from the config file
+	got, err := s.Get(ctx, legacyServer)
+	if err != nil {
+		t.Fatal("store.Get() error =", err)
+	}
+	if got != legacyCred {
+		t.Errorf("store.Get() = %v, want %v", got, legacyCred)
+	}
+
+	// test saving a new credential into the native store only
+	newServer := "new.example.com"
+	newCred := auth.Credential{
+		RefreshToken: "identity_token",
+	}
+	if err := s.Put(ctx, newServer, newCred); err != nil {
+		t.Fatal("store.Put() error =", err)
+	}
+	if got, err = s.Get(ctx, newServer); err != nil {
+		t.Fatal("store.Get() error =", err)
+	}
+	if got != newCred {
+		t.Errorf("store.Get() = %v, want %v", got, newCred)
+	}
+	if list := listTestHelper(t, helperPath); len(list) != 1 {
+		t.Errorf("native store contains %d credentials, want 1", len(list))
+	}
+	gotJSON, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("failed to read config file: %v", err)
+	}
+	if !reflect.DeepEqual(gotJSON, jsonStr) {
+		t.Errorf("config file = %s, want %s", gotJSON, jsonStr)
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// eventBufferSize is the number of events buffered for each subscriber of an
+// ObservableStore.
+const eventBufferSize = 16
+
+// CredentialOperation is an operation changing the credentials of a store.
+type CredentialOperation int
+
+const (
+	// OperationPut indicates that credentials were saved.
+	OperationPut CredentialOperation = iota
+	// OperationDelete indicates that credentials were removed.
+	OperationDelete
+)
+
+// String returns the string representation of the CredentialOperation.
+func (op CredentialOperation) String() string {
+	switch op {
+	case OperationPut:
+		return "put"
+	case OperationDelete:
+		return "delete"
+	default:
+		return fmt.Sprintf("CredentialOperation(%d)", int(op))
+	}
+}
+
+// CredentialEvent describes a change of the credentials of a store. It never
+// carries the secrets.
+type CredentialEvent struct {
+	// ServerAddress is the server address whose credentials changed.
+	ServerAddress string
+	// Operation is the operation that changed the credentials.
+	Operation CredentialOperation
+}
+
+// ObservableStore is a store notifying subscribers of the credential changes
+// made through it.
+type ObservableStore struct {
+	inner       Store
+	lock        sync.Mutex
+	subscribers map[chan CredentialEvent]struct{}
+}
+
+// NewObservableStore returns an ObservableStore passing Get(), Put() and
+// Delete() calls to the inner store. Changes made to the inner store by
+// other means, including other processes, are not observed.
+func NewObservableStore(inner Store) *ObservableStore {
+	return &ObservableStore{
+		inner:       inner,
+		subscribers: make(map[chan CredentialEvent]struct{}),
+	}
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (s *ObservableStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	return s.inner.Get(ctx, serverAddress)
+}
+
+// Put saves credentials into the store for the given server address, and
+// notifies the subscribers on success.
+func (s *ObservableStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	if err := s.inner.Put(ctx, serverAddress, cred); err != nil {
+		return err
+	}
+	s.publish(CredentialEvent{ServerAddress: serverAddress, Operation: OperationPut})
+	return nil
+}
+
+// Delete removes credentials from the store for the given server address,
+// and notifies the subscribers on success.
+func (s *ObservableStore) Delete(ctx context.Context, serverAddress string) error {
+	if err := s.inner.Delete(ctx, serverAddress); err != nil {
+		return err
+	}
+	s.publish(CredentialEvent{ServerAddress: serverAddress, Operation: OperationDelete})
+	return nil
+}
+
+// Subscribe returns a channel receiving an event for each successful Put()
+// and Delete() made through the store, and a function to unsubscribe, which
+// closes the channel. Events are buffered, and dropped for a subscriber whose
+// buffer is full, so that slow subscribers never block store operations.
+func (s *ObservableStore) Subscribe() (<-chan CredentialEvent, func()) {
+	ch := make(chan CredentialEvent, eventBufferSize)
+	s.lock.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.lock.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			s.lock.Lock()
+			defer s.lock.Unlock()
+			delete(s.subscribers, ch)
+			close(ch)
+		})
+	}
+	return ch, unsubscribe
+}
+
+// publish sends event to all the subscribers without blocking.
+func (s *ObservableStore) publish(event CredentialEvent) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for ch := range s.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// drop the event for the slow subscriber
+		}
+	}
+}
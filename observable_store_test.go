@@ -0,0 +1,130 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestObservableStore_Subscribe(t *testing.T) {
+	ctx := context.Background()
+	obs := NewObservableStore(NewMemoryStore())
+	events, unsubscribe := obs.Subscribe()
+	serverAddress := "registry.example.com"
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+
+	if err := obs.Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("ObservableStore.Put() error =", err)
+	}
+	got, err := obs.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("ObservableStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("ObservableStore.Get() = %v, want %v", got, cred)
+	}
+	if err := obs.Delete(ctx, serverAddress); err != nil {
+		t.Fatal("ObservableStore.Delete() error =", err)
+	}
+
+	unsubscribe()
+	// unsubscribing again should be a no-op
+	unsubscribe()
+	// no more events should be received after unsubscribing
+	if err := obs.Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("ObservableStore.Put() error =", err)
+	}
+
+	var gotEvents []CredentialEvent
+	for event := range events {
+		gotEvents = append(gotEvents, event)
+	}
+	wantEvents := []CredentialEvent{
+		{ServerAddress: serverAddress, Operation: OperationPut},
+		{ServerAddress: serverAddress, Operation: OperationDelete},
+	}
+	if len(gotEvents) != len(wantEvents) {
+		t.Fatalf("received events = %v, want %v", gotEvents, wantEvents)
+	}
+	for i := range wantEvents {
+		if gotEvents[i] != wantEvents[i] {
+			t.Errorf("received events = %v, want %v", gotEvents, wantEvents)
+			break
+		}
+	}
+}
+
+func TestObservableStore_slowSubscriber(t *testing.T) {
+	ctx := context.Background()
+	obs := NewObservableStore(NewMemoryStore())
+	events, unsubscribe := obs.Subscribe()
+	defer unsubscribe()
+
+	// operations should not block even if nobody receives the events
+	for i := 0; i < eventBufferSize+1; i++ {
+		if err := obs.Delete(ctx, "registry.example.com"); err != nil {
+			t.Fatal("ObservableStore.Delete() error =", err)
+		}
+	}
+	if got := len(events); got != eventBufferSize {
+		t.Errorf("buffered events = %d, want %d", got, eventBufferSize)
+	}
+}
+
+func TestObservableStore_badStore(t *testing.T) {
+	ctx := context.Background()
+	obs := NewObservableStore(&badStore{})
+	events, unsubscribe := obs.Subscribe()
+	serverAddress := "registry.example.com"
+	if _, err := obs.Get(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("ObservableStore.Get() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := obs.Put(ctx, serverAddress, auth.EmptyCredential); !errors.Is(err, errBadStore) {
+		t.Errorf("ObservableStore.Put() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := obs.Delete(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("ObservableStore.Delete() error = %v, wantErr %v", err, errBadStore)
+	}
+
+	// failed operations should not be notified
+	unsubscribe()
+	if event, ok := <-events; ok {
+		t.Errorf("received event = %v, want none", event)
+	}
+}
+
+func TestCredentialOperation_String(t *testing.T) {
+	tests := []struct {
+		op   CredentialOperation
+		want string
+	}{
+		{OperationPut, "put"},
+		{OperationDelete, "delete"},
+		{CredentialOperation(42), "CredentialOperation(42)"},
+	}
+	for _, tt := range tests {
+		if got := tt.op.String(); got != tt.want {
+			t.Errorf("CredentialOperation.String() = %v, want %v", got, tt.want)
+		}
+	}
+}
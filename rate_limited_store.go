@@ -0,0 +1,105 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// rateLimitedStore is a store spacing out operations in time.
+type rateLimitedStore struct {
+	inner    Store
+	interval time.Duration
+	lock     sync.Mutex
+	// next is the earliest time the next operation may start.
+	next time.Time
+}
+
+// NewRateLimitedStore returns a store that starts at most one Get(), Put() or
+// Delete() call on the inner store per interval. Further calls wait for their
+// turn, or return the context error if the context is done first.
+//
+// Wrapping a store created by NewNativeStore smooths bursts of helper
+// invocations, preventing cloud credential helpers from hitting the rate
+// limits of their backing APIs. Unlike NewConcurrencyLimitedStore, the calls
+// are spaced out even if each of them completes quickly. If interval is not
+// positive, the inner store is returned as is.
+func NewRateLimitedStore(inner Store, interval time.Duration) Store {
+	if interval <= 0 {
+		return inner
+	}
+	return &rateLimitedStore{
+		inner:    inner,
+		interval: interval,
+	}
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (rs *rateLimitedStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	if err := rs.wait(ctx); err != nil {
+		return auth.EmptyCredential, err
+	}
+	return rs.inner.Get(ctx, serverAddress)
+}
+
+// Put saves credentials into the store for the given server address.
+func (rs *rateLimitedStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	if err := rs.wait(ctx); err != nil {
+		return err
+	}
+	return rs.inner.Put(ctx, serverAddress, cred)
+}
+
+// Delete removes credentials from the store for the given server address.
+func (rs *rateLimitedStore) Delete(ctx context.Context, serverAddress string) error {
+	if err := rs.wait(ctx); err != nil {
+		return err
+	}
+	return rs.inner.Delete(ctx, serverAddress)
+}
+
+// wait reserves the next time slot and waits for it, or for ctx to be done.
+// A slot reserved by a call whose context is done is not given back.
+func (rs *rateLimitedStore) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	rs.lock.Lock()
+	now := time.Now()
+	if rs.next.Before(now) {
+		rs.next = now
+	}
+	at := rs.next
+	rs.next = rs.next.Add(rs.interval)
+	rs.lock.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
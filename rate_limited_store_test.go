@@ -0,0 +1,93 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestRateLimitedStore_Get_spacedOut(t *testing.T) {
+	ctx := context.Background()
+	const interval = 50 * time.Millisecond
+	rs := NewRateLimitedStore(NewMemoryStore(), interval)
+
+	const calls = 4
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := rs.Get(ctx, "registry.example.com"); err != nil {
+				t.Error("rateLimitedStore.Get() error =", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if elapsed, want := time.Since(start), (calls-1)*interval; elapsed < want {
+		t.Errorf("%d Get() calls took %v, want at least %v", calls, elapsed, want)
+	}
+}
+
+func TestRateLimitedStore_contextDone(t *testing.T) {
+	rs := NewRateLimitedStore(NewMemoryStore(), time.Hour)
+	serverAddress := "registry.example.com"
+
+	// take the immediately available slot
+	if _, err := rs.Get(context.Background(), serverAddress); err != nil {
+		t.Fatal("rateLimitedStore.Get() error =", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := rs.Get(ctx, serverAddress); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("rateLimitedStore.Get() error = %v, wantErr %v", err, context.DeadlineExceeded)
+	}
+	if err := rs.Put(ctx, serverAddress, auth.EmptyCredential); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("rateLimitedStore.Put() error = %v, wantErr %v", err, context.DeadlineExceeded)
+	}
+	if err := rs.Delete(ctx, serverAddress); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("rateLimitedStore.Delete() error = %v, wantErr %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestRateLimitedStore_noLimit(t *testing.T) {
+	inner := NewMemoryStore()
+	if got := NewRateLimitedStore(inner, 0); got != inner {
+		t.Errorf("NewRateLimitedStore() = %v, want the inner store", got)
+	}
+}
+
+func TestRateLimitedStore_badStore(t *testing.T) {
+	ctx := context.Background()
+	rs := NewRateLimitedStore(&badStore{}, time.Millisecond)
+	serverAddress := "registry.example.com"
+	if _, err := rs.Get(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("rateLimitedStore.Get() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := rs.Put(ctx, serverAddress, auth.EmptyCredential); !errors.Is(err, errBadStore) {
+		t.Errorf("rateLimitedStore.Put() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := rs.Delete(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("rateLimitedStore.Delete() error = %v, wantErr %v", err, errBadStore)
+	}
+}
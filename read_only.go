@@ -0,0 +1,80 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// ErrStoreReadOnly is returned by Put() and Delete() of a read-only store.
+var ErrStoreReadOnly = errors.New("store is read-only")
+
+// ReadOnlyChecker is an optional interface that a Store can implement to
+// report whether it is read-only.
+type ReadOnlyChecker interface {
+	// ReadOnly returns true if Put() and Delete() of the store always fail.
+	ReadOnly() bool
+}
+
+// IsReadOnly returns whether the given store is read-only, so that callers
+// can skip writes before attempting them. Stores that do not implement
+// [ReadOnlyChecker] are considered writable.
+func IsReadOnly(s Store) bool {
+	if checker, ok := s.(ReadOnlyChecker); ok {
+		return checker.ReadOnly()
+	}
+	return false
+}
+
+// readOnlyWrapper is a store rejecting all writes.
+type readOnlyWrapper struct {
+	inner Store
+}
+
+// NewReadOnlyStore returns a store whose Put() and Delete() return
+// ErrStoreReadOnly without calling the inner store, so neither the config
+// file is rewritten nor any credential helper is invoked, which suits config
+// files mounted read-only, for example in CI. Get() is passed to the inner
+// store as is.
+//
+// The returned store implements [ReadOnlyChecker].
+func NewReadOnlyStore(inner Store) Store {
+	return &readOnlyWrapper{inner: inner}
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (rs *readOnlyWrapper) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	return rs.inner.Get(ctx, serverAddress)
+}
+
+// Put returns ErrStoreReadOnly.
+func (rs *readOnlyWrapper) Put(_ context.Context, serverAddress string, _ auth.Credential) error {
+	return fmt.Errorf("%w: cannot save the credentials for %s", ErrStoreReadOnly, serverAddress)
+}
+
+// Delete returns ErrStoreReadOnly.
+func (rs *readOnlyWrapper) Delete(_ context.Context, serverAddress string) error {
+	return fmt.Errorf("%w: cannot remove the credentials for %s", ErrStoreReadOnly, serverAddress)
+}
+
+// ReadOnly returns true.
+func (rs *readOnlyWrapper) ReadOnly() bool {
+	return true
+}
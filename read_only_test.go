@@ -0,0 +1,117 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// readOnlyStore is a store reporting whether it is read-only, used for
+// testing purpose.
+type readOnlyStore struct {
+	badStore
+	readOnly bool
+}
+
+func (s *readOnlyStore) ReadOnly() bool {
+	return s.readOnly
+}
+
+func TestIsReadOnly(t *testing.T) {
+	tests := []struct {
+		name  string
+		store Store
+		want  bool
+	}{
+		{
+			name:  "store not implementing ReadOnlyChecker",
+			store: NewMemoryStore(),
+			want:  false,
+		},
+		{
+			name:  "read-only store",
+			store: &readOnlyStore{readOnly: true},
+			want:  true,
+		},
+		{
+			name:  "store returned by NewReadOnlyStore",
+			store: NewReadOnlyStore(NewMemoryStore()),
+			want:  true,
+		},
+		{
+			name:  "writable store implementing ReadOnlyChecker",
+			store: &readOnlyStore{readOnly: false},
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsReadOnly(tt.store); got != tt.want {
+				t.Errorf("IsReadOnly() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadOnlyStore(t *testing.T) {
+	ctx := context.Background()
+	inner := NewMemoryStore()
+	serverAddress := "registry.example.com"
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := inner.Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("MemoryStore.Put() error =", err)
+	}
+	rs := NewReadOnlyStore(inner)
+
+	// test get
+	got, err := rs.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("readOnlyWrapper.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("readOnlyWrapper.Get() = %v, want %v", got, cred)
+	}
+
+	// test put and delete
+	if err := rs.Put(ctx, serverAddress, auth.EmptyCredential); !errors.Is(err, ErrStoreReadOnly) {
+		t.Errorf("readOnlyWrapper.Put() error = %v, wantErr %v", err, ErrStoreReadOnly)
+	}
+	if err := rs.Delete(ctx, serverAddress); !errors.Is(err, ErrStoreReadOnly) {
+		t.Errorf("readOnlyWrapper.Delete() error = %v, wantErr %v", err, ErrStoreReadOnly)
+	}
+
+	// the inner store should be untouched
+	if got, err = inner.Get(ctx, serverAddress); err != nil {
+		t.Fatal("MemoryStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("MemoryStore.Get() = %v, want %v", got, cred)
+	}
+}
+
+func TestReadOnlyStore_badStore(t *testing.T) {
+	rs := NewReadOnlyStore(&badStore{})
+	if _, err := rs.Get(context.Background(), "registry.example.com"); !errors.Is(err, errBadStore) {
+		t.Errorf("readOnlyWrapper.Get() error = %v, wantErr %v", err, errBadStore)
+	}
+}
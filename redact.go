@@ -0,0 +1,54 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"fmt"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+const (
+	redactedSecret = "******"
+	redactedSet    = "<set>"
+	redactedUnset  = "<unset>"
+)
+
+// Redact formats the given credential for safe display, for example in logs
+// or terminals. The username is shown as is while the secrets are never
+// shown:
+//
+//	username=goodbye password=****** refresh=<unset> access=<unset>
+func Redact(cred auth.Credential) string {
+	username := cred.Username
+	if username == "" {
+		username = redactedUnset
+	}
+	password := redactedUnset
+	if cred.Password != "" {
+		password = redactedSecret
+	}
+	return fmt.Sprintf("username=%s password=%s refresh=%s access=%s",
+		username, password, presence(cred.RefreshToken), presence(cred.AccessToken))
+}
+
+// presence returns whether the secret is set without revealing it.
+func presence(secret string) string {
+	if secret == "" {
+		return redactedUnset
+	}
+	return redactedSet
+}
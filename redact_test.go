@@ -0,0 +1,68 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"strings"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name string
+		cred auth.Credential
+		want string
+	}{
+		{
+			name: "empty",
+			cred: auth.EmptyCredential,
+			want: "username=<unset> password=<unset> refresh=<unset> access=<unset>",
+		},
+		{
+			name: "basic",
+			cred: auth.Credential{
+				Username: "goodbye",
+				Password: "hello",
+			},
+			want: "username=goodbye password=****** refresh=<unset> access=<unset>",
+		},
+		{
+			name: "all fields",
+			cred: auth.Credential{
+				Username:     "goodbye",
+				Password:     "hello",
+				RefreshToken: "identity_token",
+				AccessToken:  "registry_token",
+			},
+			want: "username=goodbye password=****** refresh=<set> access=<set>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Redact(tt.cred)
+			if got != tt.want {
+				t.Errorf("Redact() = %v, want %v", got, tt.want)
+			}
+			for _, secret := range []string{tt.cred.Password, tt.cred.RefreshToken, tt.cred.AccessToken} {
+				if secret != "" && strings.Contains(got, secret) {
+					t.Errorf("Redact() = %v, leaks secret %q", got, secret)
+				}
+			}
+		})
+	}
+}
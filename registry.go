@@ -17,6 +17,9 @@ package credentials
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"strings"
 
 	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras-go/v2/registry/remote/auth"
@@ -43,6 +46,85 @@ func Login(ctx context.Context, store Store, reg *remote.Registry, cred auth.Cre
 	return credentials.Login(ctx, store, reg, cred)
 }
 
+// LoginOptions provides options for LoginWithOptions.
+type LoginOptions struct {
+	// Ping validates the credentials against the registry before they are
+	// stored. The given registry is a clone of the target registry whose
+	// client is configured with the credentials to be validated, so Ping can
+	// use it to send authenticated requests.
+	//
+	// If Ping is nil, the registry is validated by [remote.Registry.Ping],
+	// which requests the standard "/v2/" endpoint. Registries that expose
+	// the API under a different root, for example behind a path prefix,
+	// can be supported by a Ping function requesting that root instead.
+	Ping func(ctx context.Context, reg *remote.Registry) error
+}
+
+// LoginWithOptions provides the login functionality with the given
+// credentials and options. The target registry's client should be nil or of
+// type *auth.Client. Like [Login], LoginWithOptions uses a client local to
+// the function and will not modify the original client of the registry.
+//
+// The scheme used to reach the registry is controlled by the PlainHTTP field
+// of reg.
+func LoginWithOptions(ctx context.Context, store Store, reg *remote.Registry, cred auth.Credential, opts LoginOptions) error {
+	if opts.Ping == nil {
+		return Login(ctx, store, reg, cred)
+	}
+	if err := pingWithCredential(ctx, reg, cred, opts.Ping); err != nil {
+		return err
+	}
+	hostname := ServerAddressFromRegistry(reg.Reference.Registry)
+	if err := store.Put(ctx, hostname, cred); err != nil {
+		return fmt.Errorf("failed to store the credentials for %s: %w", hostname, err)
+	}
+	return nil
+}
+
+// pingWithCredential validates cred against reg with ping. Like [Login], it
+// uses a client local to the function and will not modify the original
+// client of the registry.
+func pingWithCredential(ctx context.Context, reg *remote.Registry, cred auth.Credential, ping func(ctx context.Context, reg *remote.Registry) error) error {
+	// create a clone of the original registry for login purpose
+	regClone := cloneRegistry(reg)
+	// we use the original client if applicable, otherwise use a default client
+	var authClient auth.Client
+	if reg.Client == nil {
+		authClient = *auth.DefaultClient
+		authClient.Cache = nil // no cache
+	} else if client, ok := reg.Client.(*auth.Client); ok {
+		authClient = *client
+	} else {
+		return ErrClientTypeUnsupported
+	}
+	regClone.Client = &authClient
+	// update credentials with the client
+	authClient.Credential = auth.StaticCredential(reg.Reference.Registry, cred)
+	// validate the credential
+	if err := ping(ctx, regClone); err != nil {
+		return fmt.Errorf("failed to validate the credentials for %s: %w", regClone.Reference.Registry, err)
+	}
+	return nil
+}
+
+// cloneRegistry returns a copy of reg without copying its internal state.
+func cloneRegistry(reg *remote.Registry) *remote.Registry {
+	return &remote.Registry{
+		RepositoryOptions: remote.RepositoryOptions{
+			Client:               reg.Client,
+			Reference:            reg.Reference,
+			PlainHTTP:            reg.PlainHTTP,
+			ManifestMediaTypes:   reg.ManifestMediaTypes,
+			TagListPageSize:      reg.TagListPageSize,
+			ReferrerListPageSize: reg.ReferrerListPageSize,
+			MaxMetadataBytes:     reg.MaxMetadataBytes,
+			SkipReferrersGC:      reg.SkipReferrersGC,
+			HandleWarning:        reg.HandleWarning,
+		},
+		RepositoryListPageSize: reg.RepositoryListPageSize,
+	}
+}
+
 // Logout provides the logout functionality given the registry name.
 //
 // Deprecated: This funciton now simply calls [credentials.Logout] of oras-go.
@@ -53,12 +135,101 @@ func Logout(ctx context.Context, store Store, registryName string) error {
 }
 
 // Credential returns a Credential() function that can be used by auth.Client.
+// The returned function prefers the credential carried by the context via
+// [WithCredentialOverride], if any, over the one in the store.
 //
-// Deprecated: This funciton now simply calls [credentials.Credential] of oras-go.
+// Deprecated: Apart from honoring [WithCredentialOverride], this funciton now
+// simply calls [credentials.Credential] of oras-go.
 //
 // [credentials.Credential]: https://pkg.go.dev/oras.land/oras-go/v2/registry/remote/credentials#Credential
 func Credential(store Store) func(context.Context, string) (auth.Credential, error) {
-	return credentials.Credential(store)
+	credFunc := credentials.Credential(store)
+	return func(ctx context.Context, hostport string) (auth.Credential, error) {
+		if cred, ok := credentialOverride(ctx, ServerAddressFromHostname(hostport)); ok {
+			return cred, nil
+		}
+		return credFunc(ctx, hostport)
+	}
+}
+
+// CredentialFromStores returns a Credential() function that can be used by
+// auth.Client. The returned function looks up the requested registry in each
+// of the given stores in order, and returns the first non-empty credential,
+// or any error encountered on the way. Unlike [NewStoreWithFallbacks], the
+// stores are only read and need not be combined into a single Store.
+func CredentialFromStores(stores ...Store) func(context.Context, string) (auth.Credential, error) {
+	credFuncs := make([]func(context.Context, string) (auth.Credential, error), len(stores))
+	for i, store := range stores {
+		credFuncs[i] = Credential(store)
+	}
+	return func(ctx context.Context, hostport string) (auth.Credential, error) {
+		for _, credFunc := range credFuncs {
+			cred, err := credFunc(ctx, hostport)
+			if err != nil {
+				return auth.EmptyCredential, err
+			}
+			if cred != auth.EmptyCredential {
+				return cred, nil
+			}
+		}
+		return auth.EmptyCredential, nil
+	}
+}
+
+// CredentialOptions provides options for CredentialWithOptions.
+type CredentialOptions struct {
+	// FallbackToHostname makes the returned function look up the credential
+	// stored under the bare host when nothing is stored for a server address
+	// of the form "host:port".
+	//
+	// Like the Docker CLI, [Login] stores credentials under the registry as
+	// given, port included, and the lookup is keyed by the host and port the
+	// client connects to. Registries listening on a non-default port but
+	// whose credentials are saved under the bare host, for example by
+	// another tool, are only matched with this option set.
+	FallbackToHostname bool
+}
+
+// CredentialWithOptions returns a Credential() function that can be used by
+// auth.Client, like [Credential], with the given options.
+func CredentialWithOptions(store Store, opts CredentialOptions) func(context.Context, string) (auth.Credential, error) {
+	credFunc := Credential(store)
+	if !opts.FallbackToHostname {
+		return credFunc
+	}
+	return func(ctx context.Context, hostport string) (auth.Credential, error) {
+		cred, err := credFunc(ctx, hostport)
+		if err != nil || cred != auth.EmptyCredential {
+			return cred, err
+		}
+		host, _, err := net.SplitHostPort(hostport)
+		if err != nil {
+			// no port in the server address
+			return auth.EmptyCredential, nil
+		}
+		return credFunc(ctx, host)
+	}
+}
+
+// StaticCredential returns a Credential() function that can be used by
+// auth.Client. The returned function provides cred for the registry
+// identified by serverAddress and an empty credential for any other registry,
+// which saves constructing a store for one-off authenticated operations.
+// Like [Credential], the host "registry-1.docker.io" is matched by the
+// registry "docker.io", and the credential carried by the context via
+// [WithCredentialOverride], if any, is preferred.
+func StaticCredential(serverAddress string, cred auth.Credential) func(context.Context, string) (auth.Credential, error) {
+	serverAddress = ServerAddressFromRegistry(serverAddress)
+	return func(ctx context.Context, hostport string) (auth.Credential, error) {
+		hostport = ServerAddressFromHostname(hostport)
+		if cred, ok := credentialOverride(ctx, hostport); ok {
+			return cred, nil
+		}
+		if hostport == "" || hostport != serverAddress {
+			return auth.EmptyCredential, nil
+		}
+		return cred, nil
+	}
 }
 
 // ServerAddressFromRegistry maps a registry to a server address, which is used as
@@ -73,6 +244,29 @@ func ServerAddressFromRegistry(registry string) string {
 	return credentials.ServerAddressFromRegistry(registry)
 }
 
+// DockerCredentialKey returns the key the Docker CLI uses to store the
+// credentials of the given registry, so that keys can be reconciled with
+// credentials written by "docker login". Like the ConvertToHostname function
+// of the Docker CLI, the "http://" or "https://" scheme and any path are
+// stripped, and the registries "docker.io" and "index.docker.io" are mapped
+// to "https://index.docker.io/v1/".
+// See:
+//   - https://github.com/docker/cli/blob/v24.0.2/cli/config/credentials/file_store.go
+//   - https://github.com/moby/moby/blob/v24.0.2/registry/config.go#L25-L48
+func DockerCredentialKey(registry string) string {
+	hostname := registry
+	if strings.HasPrefix(hostname, "http://") {
+		hostname = strings.TrimPrefix(hostname, "http://")
+	} else if strings.HasPrefix(hostname, "https://") {
+		hostname = strings.TrimPrefix(hostname, "https://")
+	}
+	hostname, _, _ = strings.Cut(hostname, "/")
+	if hostname == "index.docker.io" {
+		hostname = "docker.io"
+	}
+	return ServerAddressFromRegistry(hostname)
+}
+
 // ServerAddressFromHostname maps a hostname to a server address, which is used as
 // a key for credentials store. It is expected that the traffic targetting the
 // host "registry-1.docker.io" will be redirected to "https://index.docker.io/v1/".
@@ -134,6 +134,111 @@ func TestLogin_unsupportedClient(t *testing.T) {
 	}
 }
 
+func TestLoginWithOptions_customPing(t *testing.T) {
+	// create a test registry serving the API under a path prefix
+	testUsername := "test_username"
+	testPassword := "test_password"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/prefix/v2/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		wantedAuthHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(testUsername+":"+testPassword))
+		authHeader := r.Header.Get("Authorization")
+		if authHeader != wantedAuthHeader {
+			w.Header().Set("Www-Authenticate", `Basic realm="Test Server"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer ts.Close()
+	uri, _ := url.Parse(ts.URL)
+	reg, err := remote.NewRegistry(uri.Host)
+	if err != nil {
+		t.Fatalf("cannot create test registry: %v", err)
+	}
+	reg.PlainHTTP = true
+	opts := LoginOptions{
+		Ping: func(ctx context.Context, reg *remote.Registry) error {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+reg.Reference.Registry+"/prefix/v2/", nil)
+			if err != nil {
+				return err
+			}
+			resp, err := reg.Client.Do(req)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				return errors.New(resp.Status)
+			}
+			return nil
+		},
+	}
+	s := &testStore{}
+	tests := []struct {
+		name    string
+		opts    LoginOptions
+		cred    auth.Credential
+		wantErr bool
+	}{
+		{
+			name: "login succeeds",
+			opts: opts,
+			cred: auth.Credential{Username: testUsername, Password: testPassword},
+		},
+		{
+			name:    "login fails (incorrect password)",
+			opts:    opts,
+			cred:    auth.Credential{Username: testUsername, Password: "whatever"},
+			wantErr: true,
+		},
+		{
+			name:    "login fails (default ping requests /v2/)",
+			opts:    LoginOptions{},
+			cred:    auth.Credential{Username: testUsername, Password: testPassword},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			err := LoginWithOptions(ctx, s, reg, tt.cred, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoginWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			got := s.storage[reg.Reference.Registry]
+			if tt.wantErr {
+				if got != auth.EmptyCredential {
+					t.Fatalf("Stored credential = %v, want %v", got, auth.EmptyCredential)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.cred) {
+				t.Fatalf("Stored credential = %v, want %v", got, tt.cred)
+			}
+			s.Delete(ctx, reg.Reference.Registry)
+		})
+	}
+}
+
+func TestLoginWithOptions_unsupportedClient(t *testing.T) {
+	var testClient http.Client
+	reg, err := remote.NewRegistry("whatever")
+	if err != nil {
+		t.Fatalf("cannot create test registry: %v", err)
+	}
+	reg.Client = &testClient
+	opts := LoginOptions{
+		Ping: func(ctx context.Context, reg *remote.Registry) error {
+			return nil
+		},
+	}
+	err = LoginWithOptions(context.Background(), &testStore{}, reg, auth.EmptyCredential, opts)
+	if wantErr := ErrClientTypeUnsupported; !errors.Is(err, wantErr) {
+		t.Errorf("LoginWithOptions() error = %v, wantErr %v", err, wantErr)
+	}
+}
+
 func TestLogout(t *testing.T) {
 	// create a test store
 	s := &testStore{}
@@ -173,6 +278,26 @@ func TestLogout(t *testing.T) {
 	}
 }
 
+func TestLogout_dockerIO(t *testing.T) {
+	s := &testStore{}
+	s.storage = map[string]auth.Credential{
+		"https://index.docker.io/v1/": {Username: "user", Password: "word"},
+	}
+	if err := Logout(context.Background(), s, "docker.io"); err != nil {
+		t.Fatal("Logout() error =", err)
+	}
+	if s.storage["https://index.docker.io/v1/"] != auth.EmptyCredential {
+		t.Error("Credentials for https://index.docker.io/v1/ are not deleted")
+	}
+}
+
+func TestLogout_badStore(t *testing.T) {
+	err := Logout(context.Background(), &badStore{}, "localhost:2333")
+	if !errors.Is(err, errBadStore) {
+		t.Errorf("Logout() error = %v, wantErr %v", err, errBadStore)
+	}
+}
+
 func Test_mapHostname(t *testing.T) {
 	tests := []struct {
 		name string
@@ -247,3 +372,221 @@ func TestCredential(t *testing.T) {
 		})
 	}
 }
+
+func TestStaticCredential(t *testing.T) {
+	cred := auth.Credential{Username: "test_user", Password: "test_word"}
+	tests := []struct {
+		name           string
+		serverAddress  string
+		registry       string
+		wantCredential auth.Credential
+	}{
+		{
+			name:           "get credentials for the given registry",
+			serverAddress:  "localhost:2333",
+			registry:       "localhost:2333",
+			wantCredential: cred,
+		},
+		{
+			name:           "get credentials for registry-1.docker.io",
+			serverAddress:  "docker.io",
+			registry:       "registry-1.docker.io",
+			wantCredential: cred,
+		},
+		{
+			name:           "get credentials for another registry",
+			serverAddress:  "localhost:2333",
+			registry:       "localhost:6666",
+			wantCredential: auth.EmptyCredential,
+		},
+		{
+			name:           "get credentials for an empty string",
+			serverAddress:  "",
+			registry:       "",
+			wantCredential: auth.EmptyCredential,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			credFunc := StaticCredential(tt.serverAddress, cred)
+			got, err := credFunc(context.Background(), tt.registry)
+			if err != nil {
+				t.Errorf("could not get credential: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.wantCredential) {
+				t.Errorf("StaticCredential() = %v, want %v", got, tt.wantCredential)
+			}
+		})
+	}
+}
+
+func TestCredentialWithOptions(t *testing.T) {
+	// create a test store
+	s := &testStore{}
+	s.storage = map[string]auth.Credential{
+		"localhost:2333":    {Username: "test_user", Password: "test_word"},
+		"registry.test":     {Username: "host_user", Password: "host_word"},
+		"registry.test:443": {Username: "port_user", Password: "port_word"},
+	}
+	tests := []struct {
+		name           string
+		opts           CredentialOptions
+		registry       string
+		wantCredential auth.Credential
+	}{
+		{
+			name:           "get credentials for host:port",
+			opts:           CredentialOptions{FallbackToHostname: true},
+			registry:       "localhost:2333",
+			wantCredential: auth.Credential{Username: "test_user", Password: "test_word"},
+		},
+		{
+			name:           "prefer credentials stored under host:port",
+			opts:           CredentialOptions{FallbackToHostname: true},
+			registry:       "registry.test:443",
+			wantCredential: auth.Credential{Username: "port_user", Password: "port_word"},
+		},
+		{
+			name:           "fall back to credentials stored under host",
+			opts:           CredentialOptions{FallbackToHostname: true},
+			registry:       "registry.test:5000",
+			wantCredential: auth.Credential{Username: "host_user", Password: "host_word"},
+		},
+		{
+			name:           "no fallback without the option",
+			opts:           CredentialOptions{},
+			registry:       "registry.test:5000",
+			wantCredential: auth.EmptyCredential,
+		},
+		{
+			name:           "host without port is not mapped to host:port",
+			opts:           CredentialOptions{FallbackToHostname: true},
+			registry:       "localhost",
+			wantCredential: auth.EmptyCredential,
+		},
+		{
+			name:           "get credentials for an empty string",
+			opts:           CredentialOptions{FallbackToHostname: true},
+			registry:       "",
+			wantCredential: auth.EmptyCredential,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			credFunc := CredentialWithOptions(s, tt.opts)
+			got, err := credFunc(context.Background(), tt.registry)
+			if err != nil {
+				t.Errorf("could not get credential: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.wantCredential) {
+				t.Errorf("CredentialWithOptions() = %v, want %v", got, tt.wantCredential)
+			}
+		})
+	}
+}
+
+func TestCredentialWithOptions_badStore(t *testing.T) {
+	credFunc := CredentialWithOptions(&badStore{}, CredentialOptions{FallbackToHostname: true})
+	if _, err := credFunc(context.Background(), "registry.test:5000"); !errors.Is(err, errBadStore) {
+		t.Errorf("CredentialWithOptions() error = %v, wantErr %v", err, errBadStore)
+	}
+}
+
+func TestCredentialFromStores(t *testing.T) {
+	// create test stores
+	s1 := &testStore{}
+	s1.storage = map[string]auth.Credential{
+		"localhost:2333": {Username: "test_user", Password: "test_word"},
+	}
+	s2 := &testStore{}
+	s2.storage = map[string]auth.Credential{
+		"localhost:2333":              {Username: "other_user", Password: "other_word"},
+		"https://index.docker.io/v1/": {Username: "user", Password: "word"},
+	}
+	credFunc := CredentialFromStores(s1, s2)
+	tests := []struct {
+		name           string
+		registry       string
+		wantCredential auth.Credential
+	}{
+		{
+			name:           "get credentials from the first store",
+			registry:       "localhost:2333",
+			wantCredential: auth.Credential{Username: "test_user", Password: "test_word"},
+		},
+		{
+			name:           "get credentials from the second store",
+			registry:       "registry-1.docker.io",
+			wantCredential: auth.Credential{Username: "user", Password: "word"},
+		},
+		{
+			name:           "get credentials for a registry not stored",
+			registry:       "localhost:6666",
+			wantCredential: auth.EmptyCredential,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := credFunc(context.Background(), tt.registry)
+			if err != nil {
+				t.Errorf("could not get credential: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.wantCredential) {
+				t.Errorf("CredentialFromStores() = %v, want %v", got, tt.wantCredential)
+			}
+		})
+	}
+}
+
+func TestCredentialFromStores_badStore(t *testing.T) {
+	credFunc := CredentialFromStores(&testStore{}, &badStore{})
+	if _, err := credFunc(context.Background(), "localhost:2333"); !errors.Is(err, errBadStore) {
+		t.Errorf("CredentialFromStores() error = %v, wantErr %v", err, errBadStore)
+	}
+}
+
+func TestDockerCredentialKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		registry string
+		want     string
+	}{
+		{
+			name:     "map docker.io to https://index.docker.io/v1/",
+			registry: "docker.io",
+			want:     "https://index.docker.io/v1/",
+		},
+		{
+			name:     "map index.docker.io to https://index.docker.io/v1/",
+			registry: "index.docker.io",
+			want:     "https://index.docker.io/v1/",
+		},
+		{
+			name:     "keep https://index.docker.io/v1/",
+			registry: "https://index.docker.io/v1/",
+			want:     "https://index.docker.io/v1/",
+		},
+		{
+			name:     "do not map other host names",
+			registry: "localhost:2333",
+			want:     "localhost:2333",
+		},
+		{
+			name:     "strip https scheme and path",
+			registry: "https://registry.example.com/v2/",
+			want:     "registry.example.com",
+		},
+		{
+			name:     "strip http scheme",
+			registry: "http://localhost:2333",
+			want:     "localhost:2333",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DockerCredentialKey(tt.registry); got != tt.want {
+				t.Errorf("DockerCredentialKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// ReloadingStore is a dynamic store that reloads its config file when the file
+// is changed by other processes. It is safe for concurrent use.
+type ReloadingStore struct {
+	configPath string
+	options    StoreOptions
+
+	lock  sync.Mutex
+	store *DynamicStore
+	state configFileState
+}
+
+// configFileState identifies a version of a config file.
+type configFileState struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+// NewReloadingStore returns a ReloadingStore based on the given configuration
+// file and options. See [NewStore] for the handling of configPath and opts.
+//
+// Before each Get(), Put() and Delete(), the size and the modification time of
+// the config file are checked, and the config file is loaded again if they
+// have changed, so that credentials saved by "docker login" in another process
+// are observed by long-running processes. Checking before Put() and Delete()
+// also keeps this store from overwriting the changes of other processes with a
+// stale copy of the config file.
+//
+// The check is not atomic with the operation itself: the config file may still
+// change between the check and the operation, and concurrent writers may
+// still overwrite each other's changes. Changes preserving both the size and
+// the modification time, which may happen on file systems with a coarse
+// timestamp granularity, are not detected; call Reload() to force a reload.
+func NewReloadingStore(configPath string, opts StoreOptions) (*ReloadingStore, error) {
+	rs := &ReloadingStore{
+		configPath: configPath,
+		options:    opts,
+	}
+	if err := rs.Reload(); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+// Reload loads the config file again, regardless of whether it has changed.
+// The previously loaded config is kept if the config file cannot be loaded.
+func (rs *ReloadingStore) Reload() error {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+	state, err := statConfigFile(rs.configPath)
+	if err != nil {
+		return err
+	}
+	return rs.load(state)
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (rs *ReloadingStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	ds, err := rs.current()
+	if err != nil {
+		return auth.EmptyCredential, err
+	}
+	return ds.Get(ctx, serverAddress)
+}
+
+// Put saves credentials into the store for the given server address.
+func (rs *ReloadingStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	ds, err := rs.current()
+	if err != nil {
+		return err
+	}
+	return ds.Put(ctx, serverAddress, cred)
+}
+
+// Delete removes credentials from the store for the given server address.
+func (rs *ReloadingStore) Delete(ctx context.Context, serverAddress string) error {
+	ds, err := rs.current()
+	if err != nil {
+		return err
+	}
+	return ds.Delete(ctx, serverAddress)
+}
+
+// current returns the dynamic store, reloading the config file if it has
+// changed since it was last loaded.
+func (rs *ReloadingStore) current() (*DynamicStore, error) {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+	state, err := statConfigFile(rs.configPath)
+	if err != nil {
+		return nil, err
+	}
+	if state != rs.state {
+		if err := rs.load(state); err != nil {
+			return nil, err
+		}
+	}
+	return rs.store, nil
+}
+
+// load creates the dynamic store from the config file in the given state.
+// The caller must hold rs.lock.
+func (rs *ReloadingStore) load(state configFileState) error {
+	ds, err := NewStore(rs.configPath, rs.options)
+	if err != nil {
+		return err
+	}
+	rs.store = ds
+	rs.state = state
+	return nil
+}
+
+// statConfigFile returns the state of the config file at configPath.
+// A non-existing config file is not an error.
+func statConfigFile(configPath string) (configFileState, error) {
+	fi, err := os.Stat(configPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return configFileState{}, nil
+		}
+		return configFileState{}, err
+	}
+	return configFileState{
+		exists:  true,
+		size:    fi.Size(),
+		modTime: fi.ModTime(),
+	}, nil
+}
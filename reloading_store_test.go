@@ -0,0 +1,146 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestReloadingStore_Get_changedByOtherStore(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	opts := StoreOptions{AllowPlaintextPut: true}
+	rs, err := NewReloadingStore(configPath, opts)
+	if err != nil {
+		t.Fatal("NewReloadingStore() error =", err)
+	}
+	ctx := context.Background()
+	serverAddress := "registry.example.com"
+	if got, err := rs.Get(ctx, serverAddress); err != nil {
+		t.Fatal("ReloadingStore.Get() error =", err)
+	} else if got != auth.EmptyCredential {
+		t.Errorf("ReloadingStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+
+	// another process logs in
+	other, err := NewStore(configPath, opts)
+	if err != nil {
+		t.Fatal("NewStore() error =", err)
+	}
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := other.Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("DynamicStore.Put() error =", err)
+	}
+	got, err := rs.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("ReloadingStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("ReloadingStore.Get() = %v, want %v", got, cred)
+	}
+
+	// another process updates the credential, keeping the file size
+	newCred := auth.Credential{
+		Username: "username",
+		Password: "PASSWORD",
+	}
+	if err := other.Put(ctx, serverAddress, newCred); err != nil {
+		t.Fatal("DynamicStore.Put() error =", err)
+	}
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(configPath, future, future); err != nil {
+		t.Fatal("failed to change the modification time:", err)
+	}
+	if got, err = rs.Get(ctx, serverAddress); err != nil {
+		t.Fatal("ReloadingStore.Get() error =", err)
+	}
+	if got != newCred {
+		t.Errorf("ReloadingStore.Get() = %v, want %v", got, newCred)
+	}
+}
+
+func TestReloadingStore_Put_keepsChangesByOtherStore(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	opts := StoreOptions{AllowPlaintextPut: true}
+	rs, err := NewReloadingStore(configPath, opts)
+	if err != nil {
+		t.Fatal("NewReloadingStore() error =", err)
+	}
+	other, err := NewStore(configPath, opts)
+	if err != nil {
+		t.Fatal("NewStore() error =", err)
+	}
+	ctx := context.Background()
+	server1 := "registry1.example.com"
+	cred1 := auth.Credential{
+		Username: "username1",
+		Password: "password1",
+	}
+	if err := other.Put(ctx, server1, cred1); err != nil {
+		t.Fatal("DynamicStore.Put() error =", err)
+	}
+	server2 := "registry2.example.com"
+	cred2 := auth.Credential{
+		RefreshToken: "identity_token",
+	}
+	if err := rs.Put(ctx, server2, cred2); err != nil {
+		t.Fatal("ReloadingStore.Put() error =", err)
+	}
+
+	// both credentials should be saved in the config file
+	fs, err := NewFileStore(configPath)
+	if err != nil {
+		t.Fatal("NewFileStore() error =", err)
+	}
+	for server, want := range map[string]auth.Credential{server1: cred1, server2: cred2} {
+		got, err := fs.Get(ctx, server)
+		if err != nil {
+			t.Fatal("FileStore.Get() error =", err)
+		}
+		if got != want {
+			t.Errorf("FileStore.Get(%s) = %v, want %v", server, got, want)
+		}
+	}
+}
+
+func TestReloadingStore_invalidConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	rs, err := NewReloadingStore(configPath, StoreOptions{})
+	if err != nil {
+		t.Fatal("NewReloadingStore() error =", err)
+	}
+	if err := os.WriteFile(configPath, []byte("{"), 0600); err != nil {
+		t.Fatal("failed to write config file:", err)
+	}
+	ctx := context.Background()
+	if _, err := rs.Get(ctx, "registry.example.com"); err == nil {
+		t.Error("ReloadingStore.Get() error = nil, want error")
+	}
+	if err := rs.Reload(); err == nil {
+		t.Error("ReloadingStore.Reload() error = nil, want error")
+	}
+	if _, err := NewReloadingStore(configPath, StoreOptions{}); err == nil {
+		t.Error("NewReloadingStore() error = nil, want error")
+	}
+}
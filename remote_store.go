@@ -0,0 +1,204 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// remoteTokenUsername is the username denoting that the secret of a
+// RemoteCredential is a refresh token, as in the docker credential helper
+// protocol.
+const remoteTokenUsername = "<token>"
+
+// RemoteCredential is the credential exchanged with a RemoteCredentialService,
+// in the same format as the docker credential helper protocol.
+//
+// Reference: https://docs.docker.com/engine/reference/commandline/login/#credential-helper-protocol
+type RemoteCredential struct {
+	ServerURL string
+	Username  string
+	Secret    string
+}
+
+// RemoteCredentialService is a credential service reached over a transport,
+// such as HTTP or gRPC, whose actions mirror the "get", "store" and "erase"
+// actions of the docker credential helper protocol.
+type RemoteCredentialService interface {
+	// Get retrieves the credential for serverURL. It returns an error
+	// wrapping ErrCredentialNotFound if there is none.
+	Get(ctx context.Context, serverURL string) (RemoteCredential, error)
+	// Store saves cred for cred.ServerURL.
+	Store(ctx context.Context, cred RemoteCredential) error
+	// Erase removes the credential for serverURL.
+	Erase(ctx context.Context, serverURL string) error
+}
+
+// remoteStore is a store backed by a RemoteCredentialService.
+type remoteStore struct {
+	svc RemoteCredentialService
+}
+
+// NewRemoteStore returns a store that maps Get(), Put() and Delete() onto the
+// given credential service, allowing clients to use a shared credential
+// broker instead of local storage. Credentials are mapped the same way as by
+// the native store: a refresh token is exchanged with the "<token>" username,
+// and access tokens are not saved.
+func NewRemoteStore(svc RemoteCredentialService) Store {
+	return &remoteStore{svc: svc}
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (rs *remoteStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	remoteCred, err := rs.svc.Get(ctx, serverAddress)
+	if err != nil {
+		if errors.Is(err, ErrCredentialNotFound) {
+			return auth.EmptyCredential, nil
+		}
+		return auth.EmptyCredential, err
+	}
+	var cred auth.Credential
+	if remoteCred.Username == remoteTokenUsername {
+		cred.RefreshToken = remoteCred.Secret
+	} else {
+		cred.Username = remoteCred.Username
+		cred.Password = remoteCred.Secret
+	}
+	return cred, nil
+}
+
+// Put saves credentials into the store for the given server address.
+func (rs *remoteStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	remoteCred := RemoteCredential{
+		ServerURL: serverAddress,
+		Username:  cred.Username,
+		Secret:    cred.Password,
+	}
+	if cred.RefreshToken != "" {
+		remoteCred.Username = remoteTokenUsername
+		remoteCred.Secret = cred.RefreshToken
+	}
+	return rs.svc.Store(ctx, remoteCred)
+}
+
+// Delete removes credentials from the store for the given server address.
+func (rs *remoteStore) Delete(ctx context.Context, serverAddress string) error {
+	return rs.svc.Erase(ctx, serverAddress)
+}
+
+// httpCredentialService is a RemoteCredentialService over HTTP.
+type httpCredentialService struct {
+	client           *http.Client
+	endpoint         string
+	maxResponseBytes int
+}
+
+// NewHTTPCredentialService returns a reference implementation of
+// RemoteCredentialService over HTTP. Each action is sent as a POST request to
+// endpoint followed by "/get", "/store" or "/erase", with the same payload
+// as the helper protocol: the server URL as plain text for "get" and
+// "erase", and the JSON-encoded RemoteCredential for "store". The "get"
+// action is answered with the JSON-encoded RemoteCredential, or with the
+// status 404 if there is none. If client is nil, http.DefaultClient is used.
+// The responses are limited to DefaultMaxResponseBytes.
+func NewHTTPCredentialService(client *http.Client, endpoint string) RemoteCredentialService {
+	return NewHTTPCredentialServiceWithLimit(client, endpoint, DefaultMaxResponseBytes)
+}
+
+// NewHTTPCredentialServiceWithLimit is like [NewHTTPCredentialService], with
+// the responses limited to maxResponseBytes. Reading a larger response stops
+// with ErrResponseTooLarge. If maxResponseBytes is not positive,
+// DefaultMaxResponseBytes is used.
+func NewHTTPCredentialServiceWithLimit(client *http.Client, endpoint string, maxResponseBytes int) RemoteCredentialService {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if maxResponseBytes <= 0 {
+		maxResponseBytes = DefaultMaxResponseBytes
+	}
+	return &httpCredentialService{
+		client:           client,
+		endpoint:         strings.TrimSuffix(endpoint, "/"),
+		maxResponseBytes: maxResponseBytes,
+	}
+}
+
+// Get retrieves the credential for serverURL.
+func (hs *httpCredentialService) Get(ctx context.Context, serverURL string) (RemoteCredential, error) {
+	body, err := hs.do(ctx, "get", strings.NewReader(serverURL))
+	if err != nil {
+		return RemoteCredential{}, err
+	}
+	var cred RemoteCredential
+	if err := json.Unmarshal(body, &cred); err != nil {
+		return RemoteCredential{}, fmt.Errorf("failed to decode the credential for %s: %w", serverURL, err)
+	}
+	return cred, nil
+}
+
+// Store saves cred for cred.ServerURL.
+func (hs *httpCredentialService) Store(ctx context.Context, cred RemoteCredential) error {
+	credJSON, err := json.Marshal(cred)
+	if err != nil {
+		return err
+	}
+	_, err = hs.do(ctx, "store", bytes.NewReader(credJSON))
+	return err
+}
+
+// Erase removes the credential for serverURL.
+func (hs *httpCredentialService) Erase(ctx context.Context, serverURL string) error {
+	_, err := hs.do(ctx, "erase", strings.NewReader(serverURL))
+	return err
+}
+
+// do sends the given action to the service and returns the response body.
+func (hs *httpCredentialService) do(ctx context.Context, action string, payload io.Reader) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hs.endpoint+"/"+action, payload)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := hs.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, int64(hs.maxResponseBytes)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > hs.maxResponseBytes {
+		return nil, fmt.Errorf("%w: the response of %s %q exceeds %d bytes",
+			ErrResponseTooLarge, resp.Request.Method, resp.Request.URL, hs.maxResponseBytes)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound && action == "get":
+		return nil, ErrCredentialNotFound
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return nil, fmt.Errorf("%s %q: unexpected status code %d: %s",
+			resp.Request.Method, resp.Request.URL, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
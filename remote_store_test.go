@@ -0,0 +1,176 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// newTestCredentialService starts an HTTP credential service keeping the
+// credentials in memory, used for testing purpose.
+func newTestCredentialService(t *testing.T) *httptest.Server {
+	t.Helper()
+	var lock sync.Mutex
+	creds := make(map[string]RemoteCredential)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/creds/get", func(w http.ResponseWriter, r *http.Request) {
+		serverURL, _ := io.ReadAll(r.Body)
+		lock.Lock()
+		cred, ok := creds[string(serverURL)]
+		lock.Unlock()
+		if !ok {
+			http.Error(w, "credentials not found", http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(cred)
+	})
+	mux.HandleFunc("/creds/store", func(w http.ResponseWriter, r *http.Request) {
+		var cred RemoteCredential
+		if err := json.NewDecoder(r.Body).Decode(&cred); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		lock.Lock()
+		creds[cred.ServerURL] = cred
+		lock.Unlock()
+	})
+	mux.HandleFunc("/creds/erase", func(w http.ResponseWriter, r *http.Request) {
+		serverURL, _ := io.ReadAll(r.Body)
+		lock.Lock()
+		delete(creds, string(serverURL))
+		lock.Unlock()
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestRemoteStore(t *testing.T) {
+	ts := newTestCredentialService(t)
+	rs := NewRemoteStore(NewHTTPCredentialService(ts.Client(), ts.URL+"/creds/"))
+	ctx := context.Background()
+
+	// test get non-existing credential
+	basicServer := "basic.example.com"
+	got, err := rs.Get(ctx, basicServer)
+	if err != nil {
+		t.Fatal("remoteStore.Get() error =", err)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("remoteStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+
+	// test put and get basic credential
+	basicCred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := rs.Put(ctx, basicServer, basicCred); err != nil {
+		t.Fatal("remoteStore.Put() error =", err)
+	}
+	if got, err = rs.Get(ctx, basicServer); err != nil {
+		t.Fatal("remoteStore.Get() error =", err)
+	}
+	if got != basicCred {
+		t.Errorf("remoteStore.Get() = %v, want %v", got, basicCred)
+	}
+
+	// test put and get refresh token, which is exchanged with the "<token>"
+	// username
+	tokenServer := "token.example.com"
+	tokenCred := auth.Credential{
+		RefreshToken: "identity_token",
+	}
+	if err := rs.Put(ctx, tokenServer, tokenCred); err != nil {
+		t.Fatal("remoteStore.Put() error =", err)
+	}
+	if got, err = rs.Get(ctx, tokenServer); err != nil {
+		t.Fatal("remoteStore.Get() error =", err)
+	}
+	if got != tokenCred {
+		t.Errorf("remoteStore.Get() = %v, want %v", got, tokenCred)
+	}
+
+	// test delete
+	if err := rs.Delete(ctx, basicServer); err != nil {
+		t.Fatal("remoteStore.Delete() error =", err)
+	}
+	if got, err = rs.Get(ctx, basicServer); err != nil {
+		t.Fatal("remoteStore.Get() error =", err)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("remoteStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+}
+
+func TestRemoteStore_serviceError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+	rs := NewRemoteStore(NewHTTPCredentialService(nil, ts.URL))
+	ctx := context.Background()
+	serverAddress := "registry.example.com"
+
+	if _, err := rs.Get(ctx, serverAddress); err == nil {
+		t.Error("remoteStore.Get() error = nil, want error")
+	}
+	if err := rs.Put(ctx, serverAddress, auth.Credential{Username: "username"}); err == nil {
+		t.Error("remoteStore.Put() error = nil, want error")
+	}
+	if err := rs.Delete(ctx, serverAddress); err == nil {
+		t.Error("remoteStore.Delete() error = nil, want error")
+	}
+}
+
+func TestHTTPCredentialService_Get_notFound(t *testing.T) {
+	ts := newTestCredentialService(t)
+	svc := NewHTTPCredentialService(ts.Client(), ts.URL+"/creds")
+	if _, err := svc.Get(context.Background(), "registry.example.com"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("httpCredentialService.Get() error = %v, wantErr %v", err, ErrCredentialNotFound)
+	}
+}
+
+func TestHTTPCredentialServiceWithLimit_responseTooLarge(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(RemoteCredential{
+			ServerURL: "registry.example.com",
+			Username:  "username",
+			Secret:    strings.Repeat("s", 128),
+		})
+	}))
+	defer ts.Close()
+
+	svc := NewHTTPCredentialServiceWithLimit(ts.Client(), ts.URL, 64)
+	if _, err := svc.Get(context.Background(), "registry.example.com"); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("httpCredentialService.Get() error = %v, wantErr %v", err, ErrResponseTooLarge)
+	}
+	// the default limit should accept the response
+	svc = NewHTTPCredentialService(ts.Client(), ts.URL)
+	if _, err := svc.Get(context.Background(), "registry.example.com"); err != nil {
+		t.Error("httpCredentialService.Get() error =", err)
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// ErrCredentialNotFound is returned by a required-credential store when no
+// credential is stored for a server address. It is also returned by a
+// RemoteCredentialService having no credential for a server URL.
+var ErrCredentialNotFound = errors.New("credential not found")
+
+// requiredCredentialStore is a store that fails on missing credentials.
+type requiredCredentialStore struct {
+	inner Store
+}
+
+// NewRequiredCredentialStore returns a store whose Get() returns
+// ErrCredentialNotFound instead of an empty credential when the inner store
+// has no credential for the server address. Used as the credential source of
+// an auth.Client, it makes requests fail before reaching the registry rather
+// than being sent anonymously.
+//
+// Put() and Delete() are passed to the inner store as is.
+func NewRequiredCredentialStore(inner Store) Store {
+	return &requiredCredentialStore{inner: inner}
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (rs *requiredCredentialStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	cred, err := rs.inner.Get(ctx, serverAddress)
+	if err != nil {
+		return auth.EmptyCredential, err
+	}
+	if cred == auth.EmptyCredential {
+		return auth.EmptyCredential, fmt.Errorf("%w: %s", ErrCredentialNotFound, serverAddress)
+	}
+	return cred, nil
+}
+
+// Put saves credentials into the store for the given server address.
+func (rs *requiredCredentialStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	return rs.inner.Put(ctx, serverAddress, cred)
+}
+
+// Delete removes credentials from the store for the given server address.
+func (rs *requiredCredentialStore) Delete(ctx context.Context, serverAddress string) error {
+	return rs.inner.Delete(ctx, serverAddress)
+}
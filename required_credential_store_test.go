@@ -0,0 +1,74 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestRequiredCredentialStore(t *testing.T) {
+	ctx := context.Background()
+	rs := NewRequiredCredentialStore(NewMemoryStore())
+	serverAddress := "registry.example.com"
+
+	// test get missing credential
+	if _, err := rs.Get(ctx, serverAddress); !errors.Is(err, ErrCredentialNotFound) {
+		t.Fatalf("requiredCredentialStore.Get() error = %v, wantErr %v", err, ErrCredentialNotFound)
+	}
+
+	// test put and get credential
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := rs.Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("requiredCredentialStore.Put() error =", err)
+	}
+	got, err := rs.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("requiredCredentialStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("requiredCredentialStore.Get() = %v, want %v", got, cred)
+	}
+
+	// test delete credential
+	if err := rs.Delete(ctx, serverAddress); err != nil {
+		t.Fatal("requiredCredentialStore.Delete() error =", err)
+	}
+	if _, err := rs.Get(ctx, serverAddress); !errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("requiredCredentialStore.Get() error = %v, wantErr %v", err, ErrCredentialNotFound)
+	}
+}
+
+func TestRequiredCredentialStore_badStore(t *testing.T) {
+	ctx := context.Background()
+	rs := NewRequiredCredentialStore(&badStore{})
+	serverAddress := "registry.example.com"
+	if _, err := rs.Get(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("requiredCredentialStore.Get() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := rs.Put(ctx, serverAddress, auth.EmptyCredential); !errors.Is(err, errBadStore) {
+		t.Errorf("requiredCredentialStore.Put() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := rs.Delete(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("requiredCredentialStore.Delete() error = %v, wantErr %v", err, errBadStore)
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+
+	"golang.org/x/sync/singleflight"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// singleFlightStore is a store coalescing concurrent Get() calls.
+type singleFlightStore struct {
+	inner Store
+	group singleflight.Group
+}
+
+// NewSingleFlightStore returns a store that coalesces concurrent in-flight
+// Get() calls for the same server address into a single call to the inner
+// store, made with the context of the first caller, and shares its result.
+// Nothing is cached once the call returns, so the store is safe to use even
+// when credentials must not be cached over time.
+//
+// Put() and Delete() are passed to the inner store as is.
+func NewSingleFlightStore(inner Store) Store {
+	return &singleFlightStore{inner: inner}
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (ss *singleFlightStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	v, err, _ := ss.group.Do(serverAddress, func() (interface{}, error) {
+		return ss.inner.Get(ctx, serverAddress)
+	})
+	if err != nil {
+		return auth.EmptyCredential, err
+	}
+	return v.(auth.Credential), nil
+}
+
+// Put saves credentials into the store for the given server address.
+func (ss *singleFlightStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	return ss.inner.Put(ctx, serverAddress, cred)
+}
+
+// Delete removes credentials from the store for the given server address.
+func (ss *singleFlightStore) Delete(ctx context.Context, serverAddress string) error {
+	return ss.inner.Delete(ctx, serverAddress)
+}
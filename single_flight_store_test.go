@@ -0,0 +1,176 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// blockingStore is a store whose Get() blocks until released, used for
+// testing purpose. If inFlight is not nil, Get() signals it once in flight.
+type blockingStore struct {
+	Store
+	gets     int32
+	inFlight chan struct{}
+	release  chan struct{}
+}
+
+func (bs *blockingStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	atomic.AddInt32(&bs.gets, 1)
+	if bs.inFlight != nil {
+		bs.inFlight <- struct{}{}
+	}
+	<-bs.release
+	return bs.Store.Get(ctx, serverAddress)
+}
+
+// waitForSingleFlightWaiters waits until n goroutines are waiting for the
+// result of an in-flight call of a singleflight.Group, as reported by their
+// stack traces.
+func waitForSingleFlightWaiters(t *testing.T, n int) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	buf := make([]byte, 1<<16)
+	for {
+		size := runtime.Stack(buf, true)
+		if size == len(buf) {
+			buf = make([]byte, 2*len(buf))
+			continue
+		}
+		var waiters int
+		for _, stack := range strings.Split(string(buf[:size]), "\n\n") {
+			if strings.Contains(stack, "singleflight.(*Group).Do(") && strings.Contains(stack, "sync.(*WaitGroup).Wait(") {
+				waiters++
+			}
+		}
+		if waiters >= n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d goroutines waiting for the in-flight call, want %d", waiters, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSingleFlightStore_Get_concurrent(t *testing.T) {
+	ctx := context.Background()
+	inner := &blockingStore{
+		Store:    NewMemoryStore(),
+		inFlight: make(chan struct{}, 1),
+		release:  make(chan struct{}),
+	}
+	serverAddress := "registry.example.com"
+	want := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := inner.Put(ctx, serverAddress, want); err != nil {
+		t.Fatal("MemoryStore.Put() error =", err)
+	}
+	ss := NewSingleFlightStore(inner)
+
+	const concurrency = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, concurrency)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := ss.Get(ctx, serverAddress)
+			if err == nil && got != want {
+				err = errors.New("unexpected credential")
+			}
+			errs <- err
+		}()
+	}
+	// release the inner store once the other goroutines wait for the
+	// in-flight call
+	<-inner.inFlight
+	waitForSingleFlightWaiters(t, concurrency-1)
+	close(inner.release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error("singleFlightStore.Get() error =", err)
+		}
+	}
+	if got := atomic.LoadInt32(&inner.gets); got != 1 {
+		t.Errorf("inner store Get() called %d times, want 1", got)
+	}
+}
+
+func TestSingleFlightStore_Get_notCached(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingStore{Store: NewMemoryStore()}
+	ss := NewSingleFlightStore(inner)
+	serverAddress := "registry.example.com"
+
+	if _, err := ss.Get(ctx, serverAddress); err != nil {
+		t.Fatal("singleFlightStore.Get() error =", err)
+	}
+	cred := auth.Credential{
+		RefreshToken: "identity_token",
+	}
+	if err := ss.Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("singleFlightStore.Put() error =", err)
+	}
+	got, err := ss.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("singleFlightStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("singleFlightStore.Get() = %v, want %v", got, cred)
+	}
+	if inner.gets != 2 {
+		t.Errorf("inner store Get() called %d times, want 2", inner.gets)
+	}
+
+	if err := ss.Delete(ctx, serverAddress); err != nil {
+		t.Fatal("singleFlightStore.Delete() error =", err)
+	}
+	if got, err = ss.Get(ctx, serverAddress); err != nil {
+		t.Fatal("singleFlightStore.Get() error =", err)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("singleFlightStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+}
+
+func TestSingleFlightStore_badStore(t *testing.T) {
+	ctx := context.Background()
+	ss := NewSingleFlightStore(&badStore{})
+	serverAddress := "registry.example.com"
+	if _, err := ss.Get(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("singleFlightStore.Get() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := ss.Put(ctx, serverAddress, auth.EmptyCredential); !errors.Is(err, errBadStore) {
+		t.Errorf("singleFlightStore.Put() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := ss.Delete(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("singleFlightStore.Delete() error = %v, wantErr %v", err, errBadStore)
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// ErrCredentialTooLarge is returned by a size-limited store when the size of a
+// credential exceeds the limit.
+var ErrCredentialTooLarge = errors.New("credential too large")
+
+// sizeLimitedStore is a store that rejects credentials exceeding a size limit.
+type sizeLimitedStore struct {
+	inner    Store
+	maxBytes int
+}
+
+// NewSizeLimitedStore returns a store that limits the total length, in bytes,
+// of the Username, Password, RefreshToken and AccessToken fields of the
+// credentials going through the inner store.
+//   - Get() returns ErrCredentialTooLarge if the credential returned by the
+//     inner store exceeds maxBytes.
+//   - Put() returns ErrCredentialTooLarge without calling the inner store if
+//     the given credential exceeds maxBytes.
+//   - Delete() deletes the credential from the inner store.
+func NewSizeLimitedStore(inner Store, maxBytes int) Store {
+	return &sizeLimitedStore{
+		inner:    inner,
+		maxBytes: maxBytes,
+	}
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (ss *sizeLimitedStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	cred, err := ss.inner.Get(ctx, serverAddress)
+	if err != nil {
+		return auth.EmptyCredential, err
+	}
+	if err := ss.checkSize(serverAddress, cred); err != nil {
+		return auth.EmptyCredential, err
+	}
+	return cred, nil
+}
+
+// Put saves credentials into the store for the given server address.
+func (ss *sizeLimitedStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	if err := ss.checkSize(serverAddress, cred); err != nil {
+		return err
+	}
+	return ss.inner.Put(ctx, serverAddress, cred)
+}
+
+// Delete removes credentials from the store for the given server address.
+func (ss *sizeLimitedStore) Delete(ctx context.Context, serverAddress string) error {
+	return ss.inner.Delete(ctx, serverAddress)
+}
+
+// checkSize returns ErrCredentialTooLarge if cred exceeds the size limit.
+func (ss *sizeLimitedStore) checkSize(serverAddress string, cred auth.Credential) error {
+	size := len(cred.Username) + len(cred.Password) + len(cred.RefreshToken) + len(cred.AccessToken)
+	if size > ss.maxBytes {
+		return fmt.Errorf("%w: the credential for %s has %d bytes, exceeding the limit of %d bytes",
+			ErrCredentialTooLarge, serverAddress, size, ss.maxBytes)
+	}
+	return nil
+}
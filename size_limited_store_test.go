@@ -0,0 +1,127 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestSizeLimitedStore_Get(t *testing.T) {
+	ctx := context.Background()
+	ms := NewMemoryStore()
+	serverAddress := "registry.example.com"
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := ms.Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("MemoryStore.Put() error =", err)
+	}
+
+	// within the limit
+	ss := NewSizeLimitedStore(ms, 16)
+	got, err := ss.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("sizeLimitedStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("sizeLimitedStore.Get() = %v, want %v", got, cred)
+	}
+
+	// exceeding the limit
+	ss = NewSizeLimitedStore(ms, 15)
+	got, err = ss.Get(ctx, serverAddress)
+	if !errors.Is(err, ErrCredentialTooLarge) {
+		t.Errorf("sizeLimitedStore.Get() error = %v, wantErr %v", err, ErrCredentialTooLarge)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("sizeLimitedStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+
+	// inner store error
+	ss = NewSizeLimitedStore(&badStore{}, 16)
+	if _, err := ss.Get(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("sizeLimitedStore.Get() error = %v, wantErr %v", err, errBadStore)
+	}
+}
+
+func TestSizeLimitedStore_Put(t *testing.T) {
+	ctx := context.Background()
+	ms := NewMemoryStore()
+	ss := NewSizeLimitedStore(ms, 16)
+	serverAddress := "registry.example.com"
+
+	// exceeding the limit
+	largeCred := auth.Credential{
+		RefreshToken: "identity_token",
+		AccessToken:  "registry_token",
+	}
+	if err := ss.Put(ctx, serverAddress, largeCred); !errors.Is(err, ErrCredentialTooLarge) {
+		t.Errorf("sizeLimitedStore.Put() error = %v, wantErr %v", err, ErrCredentialTooLarge)
+	}
+	got, err := ms.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("MemoryStore.Get() error =", err)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("MemoryStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+
+	// within the limit
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := ss.Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("sizeLimitedStore.Put() error =", err)
+	}
+	got, err = ms.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("MemoryStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("MemoryStore.Get() = %v, want %v", got, cred)
+	}
+}
+
+func TestSizeLimitedStore_Delete(t *testing.T) {
+	ctx := context.Background()
+	ms := NewMemoryStore()
+	ss := NewSizeLimitedStore(ms, 16)
+	serverAddress := "registry.example.com"
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := ms.Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("MemoryStore.Put() error =", err)
+	}
+
+	if err := ss.Delete(ctx, serverAddress); err != nil {
+		t.Fatal("sizeLimitedStore.Delete() error =", err)
+	}
+	got, err := ms.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("MemoryStore.Get() error =", err)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("MemoryStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SaveTo writes the credentials of store to the file at path as a docker
+// config file holding only the "auths" field, replacing any existing file,
+// so that an in-memory store can be snapshotted to durable storage, for
+// example on shutdown. The file is written atomically with the permission
+// 0600. store must implement [Lister], as the stores returned by
+// NewMemoryStore and NewLockedMemoryStore do, otherwise ErrListNotSupported
+// is returned.
+//
+// The file contains the secrets in plaintext.
+func SaveTo(ctx context.Context, store Store, path string) error {
+	serverAddresses, err := List(ctx, store)
+	if err != nil {
+		return err
+	}
+	content, err := ExportDockerConfigJSON(ctx, store, serverAddresses)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to make directory %s: %w", dir, err)
+	}
+	ingest, err := os.CreateTemp(dir, ingestFilePattern)
+	if err != nil {
+		return fmt.Errorf("failed to create ingest file: %w", err)
+	}
+	ingestPath := ingest.Name()
+	defer func() {
+		// clean up the ingest file in case of error
+		os.Remove(ingestPath)
+	}()
+	if _, err := ingest.Write(content); err != nil {
+		ingest.Close()
+		return fmt.Errorf("failed to write ingest file %s: %w", ingestPath, err)
+	}
+	if err := ingest.Close(); err != nil {
+		return fmt.Errorf("failed to close ingest file %s: %w", ingestPath, err)
+	}
+	if err := os.Rename(ingestPath, path); err != nil {
+		return fmt.Errorf("failed to save file %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadInto reads the "auths" field of the docker config file at path, such
+// as a file written by [SaveTo], and puts each of its credentials into store.
+// A non-existing file loads no credentials.
+func LoadInto(ctx context.Context, store Store, path string) error {
+	cfg, err := loadDockerConfig(path)
+	if err != nil {
+		return err
+	}
+	for serverAddress, ac := range cfg.AuthConfigs {
+		cred, err := ac.credential()
+		if err != nil {
+			return fmt.Errorf("failed to load the credential for %s: %w", serverAddress, err)
+		}
+		if err := store.Put(ctx, serverAddress, cred); err != nil {
+			return fmt.Errorf("failed to put the credential for %s: %w", serverAddress, err)
+		}
+	}
+	return nil
+}
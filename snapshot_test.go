@@ -0,0 +1,147 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestSaveTo_LoadInto(t *testing.T) {
+	ctx := context.Background()
+	creds := map[string]auth.Credential{
+		"basic.example.com": {
+			Username: "username",
+			Password: "password",
+		},
+		"token.example.com": {
+			RefreshToken: "identity_token",
+			AccessToken:  "access_token",
+		},
+	}
+	ls := NewLockedMemoryStore()
+	for serverAddress, cred := range creds {
+		if err := ls.Put(ctx, serverAddress, cred); err != nil {
+			t.Fatal("lockedMemoryStore.Put() error =", err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "snapshot", "config.json")
+	if err := SaveTo(ctx, ls, path); err != nil {
+		t.Fatal("SaveTo() error =", err)
+	}
+	if runtime.GOOS != "windows" {
+		fi, err := os.Stat(path)
+		if err != nil {
+			t.Fatal("os.Stat() error =", err)
+		}
+		if got, want := fi.Mode().Perm(), os.FileMode(0600); got != want {
+			t.Errorf("file mode = %v, want %v", got, want)
+		}
+	}
+
+	ms := NewMemoryStore()
+	if err := LoadInto(ctx, ms, path); err != nil {
+		t.Fatal("LoadInto() error =", err)
+	}
+	for serverAddress, want := range creds {
+		got, err := ms.Get(ctx, serverAddress)
+		if err != nil {
+			t.Fatal("MemoryStore.Get() error =", err)
+		}
+		if got != want {
+			t.Errorf("MemoryStore.Get(%s) = %v, want %v", serverAddress, got, want)
+		}
+	}
+
+	// the snapshot should be readable by a file store
+	fs, err := NewFileStore(path)
+	if err != nil {
+		t.Fatal("NewFileStore() error =", err)
+	}
+	got, err := fs.Get(ctx, "basic.example.com")
+	if err != nil {
+		t.Fatal("FileStore.Get() error =", err)
+	}
+	if want := creds["basic.example.com"]; got != want {
+		t.Errorf("FileStore.Get() = %v, want %v", got, want)
+	}
+}
+
+func TestSaveTo_LoadInto_memoryStore(t *testing.T) {
+	ctx := context.Background()
+	serverAddress := "registry.example.com"
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	ms := NewMemoryStore()
+	if err := ms.Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("MemoryStore.Put() error =", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := SaveTo(ctx, ms, path); err != nil {
+		t.Fatal("SaveTo() error =", err)
+	}
+	loaded := NewMemoryStore()
+	if err := LoadInto(ctx, loaded, path); err != nil {
+		t.Fatal("LoadInto() error =", err)
+	}
+	got, err := loaded.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("MemoryStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("MemoryStore.Get() = %v, want %v", got, cred)
+	}
+}
+
+func TestSaveTo_listNotSupported(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := SaveTo(context.Background(), &testStore{}, path); !errors.Is(err, ErrListNotSupported) {
+		t.Errorf("SaveTo() error = %v, wantErr %v", err, ErrListNotSupported)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("os.Stat() error = %v, want %v", err, os.ErrNotExist)
+	}
+}
+
+func TestLoadInto_notExistFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "whatever.json")
+	if err := LoadInto(context.Background(), &badStore{}, path); err != nil {
+		t.Errorf("LoadInto() error = %v", err)
+	}
+}
+
+func TestLoadInto_badStore(t *testing.T) {
+	err := LoadInto(context.Background(), &badStore{}, "testdata/valid_auths_config.json")
+	if !errors.Is(err, errBadStore) {
+		t.Errorf("LoadInto() error = %v, wantErr %v", err, errBadStore)
+	}
+}
+
+func TestLoadInto_invalidEntry(t *testing.T) {
+	if err := LoadInto(context.Background(), NewMemoryStore(), "testdata/invalid_auths_entry_config.json"); err == nil {
+		t.Error("LoadInto() error = nil, want error")
+	}
+}
@@ -20,9 +20,33 @@ limitations under the License.
 package credentials
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
 	"oras.land/oras-go/v2/registry/remote/credentials"
 )
 
+const (
+	dockerConfigDirEnv   = "DOCKER_CONFIG"
+	dockerConfigFileDir  = ".docker"
+	dockerConfigFileName = "config.json"
+)
+
+var (
+	// defaultConfigPath is the process-wide config path set by
+	// SetDefaultConfigPath.
+	defaultConfigPath string
+	// defaultConfigPathLock guards defaultConfigPath.
+	defaultConfigPathLock sync.RWMutex
+)
+
 // Store is the interface that any credentials store must implement.
 //
 // Deprecated: This type is now simply [credentials.Store] of oras-go.
@@ -58,14 +82,46 @@ type StoreOptions = credentials.StoreOptions
 //   - https://docs.docker.com/engine/reference/commandline/login/#credentials-store
 //   - https://docs.docker.com/engine/reference/commandline/cli/#docker-cli-configuration-file-configjson-properties
 //
-// Deprecated: This funciton now simply calls [credentials.NewStore] of oras-go.
+// If the config file explicitly sets the "credsStore" field to an empty
+// string, the default native store is not detected even if
+// opts.DetectDefaultNativeStore is set, and the plain-text config file is used
+// as the user intended.
+//
+// Deprecated: Apart from honoring an empty "credsStore" field, this funciton
+// now simply calls [credentials.NewStore] of oras-go.
 //
 // [credentials.NewStore]: https://pkg.go.dev/oras.land/oras-go/v2/registry/remote/credentials#NewStore
 func NewStore(configPath string, opts StoreOptions) (*DynamicStore, error) {
+	// credentials.NewStore only accepts a config path, and the DynamicStore it
+	// returns neither tells an empty "credsStore" field from a missing one nor
+	// allows undoing the detection, so the config file has to be read before
+	// calling it. This second read only happens when the detection is enabled.
+	if opts.DetectDefaultNativeStore && isCredsStoreExplicitlyEmpty(configPath) {
+		opts.DetectDefaultNativeStore = false
+	}
 	return credentials.NewStore(configPath, opts)
 }
 
+// isCredsStoreExplicitlyEmpty reports whether the config file at configPath
+// contains the "credsStore" field set to an empty string. Failures to read the
+// config file are left to be reported by [credentials.NewStore].
+func isCredsStoreExplicitlyEmpty(configPath string) bool {
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return false
+	}
+	var cfg struct {
+		CredentialsStore *string `json:"credsStore"`
+	}
+	if err := json.Unmarshal(content, &cfg); err != nil {
+		return false
+	}
+	return cfg.CredentialsStore != nil && *cfg.CredentialsStore == ""
+}
+
 // NewStoreFromDocker returns a Store based on the default docker config file.
+//   - If a default config path is set via [SetDefaultConfigPath], it will be
+//     used.
 //   - If the $DOCKER_CONFIG environment variable is set,
 //     $DOCKER_CONFIG/config.json will be used.
 //   - Otherwise, the default location $HOME/.docker/config.json will be used.
@@ -76,11 +132,53 @@ func NewStore(configPath string, opts StoreOptions) (*DynamicStore, error) {
 //   - https://docs.docker.com/engine/reference/commandline/cli/#configuration-files
 //   - https://docs.docker.com/engine/reference/commandline/cli/#change-the-docker-directory
 //
-// Deprecated: This funciton now simply calls [credentials.NewStoreFromDocker] of oras-go.
+// Deprecated: Apart from honoring [SetDefaultConfigPath] and an empty
+// "credsStore" field, this funciton now behaves as
+// [credentials.NewStoreFromDocker] of oras-go.
 //
 // [credentials.NewStoreFromDocker]: https://pkg.go.dev/oras.land/oras-go/v2/registry/remote/credentials#NewStoreFromDocker
 func NewStoreFromDocker(opts StoreOptions) (*DynamicStore, error) {
-	return credentials.NewStoreFromDocker(opts)
+	configPath, err := dockerConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	return NewStore(configPath, opts)
+}
+
+// dockerConfigPath returns the path of the config file used by
+// [NewStoreFromDocker].
+func dockerConfigPath() (string, error) {
+	if configPath := DefaultConfigPath(); configPath != "" {
+		return configPath, nil
+	}
+	// first try the environment variable
+	configDir := os.Getenv(dockerConfigDirEnv)
+	if configDir == "" {
+		// then try home directory
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get user home directory: %w", err)
+		}
+		configDir = filepath.Join(homeDir, dockerConfigFileDir)
+	}
+	return filepath.Join(configDir, dockerConfigFileName), nil
+}
+
+// SetDefaultConfigPath sets the process-wide config path used by
+// [NewStoreFromDocker] in place of the default docker config file. Setting an
+// empty path restores the default behavior.
+func SetDefaultConfigPath(path string) {
+	defaultConfigPathLock.Lock()
+	defer defaultConfigPathLock.Unlock()
+	defaultConfigPath = path
+}
+
+// DefaultConfigPath returns the process-wide config path set by
+// [SetDefaultConfigPath], or an empty string if it is not set.
+func DefaultConfigPath() string {
+	defaultConfigPathLock.RLock()
+	defer defaultConfigPathLock.RUnlock()
+	return defaultConfigPath
 }
 
 // NewStoreWithFallbacks returns a new store based on the given stores.
@@ -96,3 +194,136 @@ func NewStoreFromDocker(opts StoreOptions) (*DynamicStore, error) {
 func NewStoreWithFallbacks(primary Store, fallbacks ...Store) Store {
 	return credentials.NewStoreWithFallbacks(primary, fallbacks...)
 }
+
+// FallbackOptions provides options for NewStoreWithFallbacksOptions.
+type FallbackOptions struct {
+	// PropagatePut makes Put() and Delete() apply to the fallback stores in
+	// addition to the primary store, for example to persist credentials put
+	// into an in-memory primary store into a file store fallback. All the
+	// stores are attempted, and the errors returned by any of them are
+	// combined into the returned error.
+	PropagatePut bool
+
+	// ContinueOnError makes Get() search the next stores when a store fails,
+	// instead of returning the error right away. If no store has the
+	// credentials, the errors returned by the stores are combined into the
+	// returned error, each labelled by the index of its store, the primary
+	// store being 0.
+	ContinueOnError bool
+}
+
+// NewStoreWithFallbacksOptions returns a new store based on the given stores
+// and options. Without options, it is equivalent to [NewStoreWithFallbacks].
+func NewStoreWithFallbacksOptions(primary Store, fallbacks []Store, opts FallbackOptions) Store {
+	sf := NewStoreWithFallbacks(primary, fallbacks...)
+	if (!opts.PropagatePut && !opts.ContinueOnError) || len(fallbacks) == 0 {
+		return sf
+	}
+	return &fallbackOptionsStore{
+		Store:  sf,
+		stores: append([]Store{primary}, fallbacks...),
+		opts:   opts,
+	}
+}
+
+// fallbackOptionsStore is a store with fallbacks applying FallbackOptions.
+type fallbackOptionsStore struct {
+	// Store is the store with fallbacks serving the calls not affected by
+	// opts.
+	Store
+	stores []Store
+	opts   FallbackOptions
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (fs *fallbackOptionsStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	if !fs.opts.ContinueOnError {
+		return fs.Store.Get(ctx, serverAddress)
+	}
+	var errs []error
+	for i, s := range fs.stores {
+		cred, err := s.Get(ctx, serverAddress)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("store %d: %w", i, err))
+			continue
+		}
+		if cred != auth.EmptyCredential {
+			return cred, nil
+		}
+	}
+	return auth.EmptyCredential, joinErrors(errs...)
+}
+
+// Put saves credentials into the store for the given server address.
+func (fs *fallbackOptionsStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	if !fs.opts.PropagatePut {
+		return fs.Store.Put(ctx, serverAddress, cred)
+	}
+	var errs []error
+	for _, s := range fs.stores {
+		if err := s.Put(ctx, serverAddress, cred); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return joinErrors(errs...)
+}
+
+// Delete removes credentials from the store for the given server address.
+func (fs *fallbackOptionsStore) Delete(ctx context.Context, serverAddress string) error {
+	if !fs.opts.PropagatePut {
+		return fs.Store.Delete(ctx, serverAddress)
+	}
+	var errs []error
+	for _, s := range fs.stores {
+		if err := s.Delete(ctx, serverAddress); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return joinErrors(errs...)
+}
+
+// joinedError is an error combining multiple errors, like the errors returned
+// by errors.Join, which is not available in Go 1.19.
+type joinedError struct {
+	errs []error
+}
+
+// joinErrors returns an error combining the non-nil errors in errs, or nil if
+// there is none.
+func joinErrors(errs ...error) error {
+	var nonNil []error
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+	if len(nonNil) == 0 {
+		return nil
+	}
+	return &joinedError{errs: nonNil}
+}
+
+// Error returns the messages of the combined errors, separated by newlines.
+func (e *joinedError) Error() string {
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the combined errors.
+func (e *joinedError) Unwrap() []error {
+	return e.errs
+}
+
+// Is reports whether any of the combined errors matches target, so that
+// errors.Is works on Go versions not supporting Unwrap() []error.
+func (e *joinedError) Is(target error) bool {
+	for _, err := range e.errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"container/list"
+	"path/filepath"
+	"sync"
+)
+
+// StoreCache is a least-recently-used cache of dynamic stores keyed by the
+// path of their config files. It is safe for concurrent use.
+type StoreCache struct {
+	size    int
+	options StoreOptions
+
+	lock    sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+// storeCacheEntry is an entry of StoreCache.
+type storeCacheEntry struct {
+	configPath string
+	store      *DynamicStore
+}
+
+// NewStoreCache returns a StoreCache holding at most size stores, all of which
+// are created with the given options. If size is not positive, the cache holds
+// a single store.
+func NewStoreCache(size int, opts StoreOptions) *StoreCache {
+	if size <= 0 {
+		size = 1
+	}
+	return &StoreCache{
+		size:    size,
+		options: opts,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// StoreForConfig returns the store based on the given configuration file.
+// The store is created by [NewStore] on the first request for configPath and
+// reused by subsequent requests until it is evicted as the least recently
+// used one.
+//
+// Since a cached store keeps the config loaded in memory, changes made to the
+// config file by other processes are not observed until the store is evicted.
+// Use [NewReloadingStore] for a store observing such changes.
+func (sc *StoreCache) StoreForConfig(configPath string) (*DynamicStore, error) {
+	key := filepath.Clean(configPath)
+
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	if elem, ok := sc.entries[key]; ok {
+		sc.order.MoveToFront(elem)
+		return elem.Value.(*storeCacheEntry).store, nil
+	}
+	ds, err := NewStore(configPath, sc.options)
+	if err != nil {
+		return nil, err
+	}
+	sc.entries[key] = sc.order.PushFront(&storeCacheEntry{
+		configPath: key,
+		store:      ds,
+	})
+	if sc.order.Len() > sc.size {
+		oldest := sc.order.Back()
+		sc.order.Remove(oldest)
+		delete(sc.entries, oldest.Value.(*storeCacheEntry).configPath)
+	}
+	return ds, nil
+}
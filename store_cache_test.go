@@ -0,0 +1,111 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestStoreCache_StoreForConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath1 := filepath.Join(tempDir, "tenant1", "config.json")
+	configPath2 := filepath.Join(tempDir, "tenant2", "config.json")
+	configPath3 := filepath.Join(tempDir, "tenant3", "config.json")
+	sc := NewStoreCache(2, StoreOptions{AllowPlaintextPut: true})
+
+	ds1, err := sc.StoreForConfig(configPath1)
+	if err != nil {
+		t.Fatal("StoreCache.StoreForConfig() error =", err)
+	}
+	if got := ds1.ConfigPath(); got != configPath1 {
+		t.Errorf("DynamicStore.ConfigPath() = %v, want %v", got, configPath1)
+	}
+	ds2, err := sc.StoreForConfig(configPath2)
+	if err != nil {
+		t.Fatal("StoreCache.StoreForConfig() error =", err)
+	}
+	if ds1 == ds2 {
+		t.Error("StoreCache.StoreForConfig() returned the same store for different config files")
+	}
+
+	// the same config file should reuse the cached store
+	got, err := sc.StoreForConfig(configPath1)
+	if err != nil {
+		t.Fatal("StoreCache.StoreForConfig() error =", err)
+	}
+	if got != ds1 {
+		t.Error("StoreCache.StoreForConfig() did not reuse the cached store")
+	}
+
+	// adding a third store should evict the least recently used one (ds2)
+	if _, err := sc.StoreForConfig(configPath3); err != nil {
+		t.Fatal("StoreCache.StoreForConfig() error =", err)
+	}
+	if got, err = sc.StoreForConfig(configPath1); err != nil {
+		t.Fatal("StoreCache.StoreForConfig() error =", err)
+	}
+	if got != ds1 {
+		t.Error("StoreCache.StoreForConfig() evicted the recently used store")
+	}
+	if got, err = sc.StoreForConfig(configPath2); err != nil {
+		t.Fatal("StoreCache.StoreForConfig() error =", err)
+	}
+	if got == ds2 {
+		t.Error("StoreCache.StoreForConfig() did not evict the least recently used store")
+	}
+}
+
+func TestStoreCache_StoreForConfig_isolation(t *testing.T) {
+	tempDir := t.TempDir()
+	sc := NewStoreCache(2, StoreOptions{AllowPlaintextPut: true})
+	ctx := context.Background()
+	serverAddress := "registry.example.com"
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+
+	ds1, err := sc.StoreForConfig(filepath.Join(tempDir, "tenant1", "config.json"))
+	if err != nil {
+		t.Fatal("StoreCache.StoreForConfig() error =", err)
+	}
+	if err := ds1.Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("DynamicStore.Put() error =", err)
+	}
+
+	ds2, err := sc.StoreForConfig(filepath.Join(tempDir, "tenant2", "config.json"))
+	if err != nil {
+		t.Fatal("StoreCache.StoreForConfig() error =", err)
+	}
+	got, err := ds2.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("DynamicStore.Get() error =", err)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("DynamicStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+}
+
+func TestStoreCache_StoreForConfig_badConfig(t *testing.T) {
+	sc := NewStoreCache(0, StoreOptions{})
+	if _, err := sc.StoreForConfig("testdata/bad_config"); err == nil {
+		t.Error("StoreCache.StoreForConfig() error = nil, want error")
+	}
+}
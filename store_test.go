@@ -16,12 +16,14 @@ limitations under the License.
 package credentials
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"os"
 	"path/filepath"
 	"reflect"
+	"runtime"
 	"testing"
 
 	"github.com/oras-project/oras-credentials-go/internal/config/configtest"
@@ -453,6 +455,180 @@ func Test_DynamicStore_noAuthConfigured_DetectDefaultNativeStore(t *testing.T) {
 	}
 }
 
+// installDefaultTestHelper installs the test credential helper as the only
+// credential helper in $PATH, under the name of the platform-default native
+// store, so that the detected default native store never reaches a real
+// keychain. It returns the path of the installed helper.
+func installDefaultTestHelper(t *testing.T) string {
+	t.Helper()
+	var helperName string
+	switch runtime.GOOS {
+	case "linux":
+		// "pass" is preferred if available, so it is hidden
+		helperName = "docker-credential-secretservice"
+	case "darwin":
+		helperName = "docker-credential-osxkeychain"
+	case "windows":
+		helperName = "docker-credential-wincred.exe"
+	default:
+		t.Skip("skipping as there is no default native store on", runtime.GOOS)
+	}
+	content, err := os.ReadFile(buildTestHelper(t))
+	if err != nil {
+		t.Fatalf("failed to read the test credential helper: %v", err)
+	}
+	helperPath := filepath.Join(t.TempDir(), helperName)
+	if err := os.WriteFile(helperPath, content, 0755); err != nil {
+		t.Fatalf("failed to install the test credential helper: %v", err)
+	}
+	t.Setenv("PATH", filepath.Dir(helperPath))
+	return helperPath
+}
+
+func Test_DynamicStore_noConfigFile_DetectDefaultNativeStore(t *testing.T) {
+	helperPath := installDefaultTestHelper(t)
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.json")
+	opts := StoreOptions{
+		AllowPlaintextPut:        true,
+		DetectDefaultNativeStore: true,
+	}
+	ds, err := NewStore(configPath, opts)
+	if err != nil {
+		t.Fatal("NewStore() error =", err)
+	}
+
+	// NewStore() and Get() should not create the config file
+	serverAddr := "test.example.com"
+	ctx := context.Background()
+	if _, err := ds.Get(ctx, serverAddr); err != nil {
+		t.Fatal("DynamicStore.Get() error =", err)
+	}
+	if _, err := os.Stat(configPath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("os.Stat() error = %v, want %v", err, os.ErrNotExist)
+	}
+
+	// the config file should be created on the first Put()
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := ds.Put(ctx, serverAddr, cred); err != nil {
+		t.Fatal("DynamicStore.Put() error =", err)
+	}
+	if _, err := os.Stat(configPath); err != nil {
+		t.Errorf("os.Stat() error = %v, want nil", err)
+	}
+	if list := listTestHelper(t, helperPath); list[serverAddr] != cred.Username {
+		t.Errorf("native store username = %v, want %v", list[serverAddr], cred.Username)
+	}
+}
+
+func Test_DynamicStore_readOnlyConstruct(t *testing.T) {
+	// prepare test content
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.json")
+	cfg := configtest.Config{
+		AuthConfigs: map[string]configtest.AuthConfig{
+			"test.example.com": {
+				Auth: "dXNlcm5hbWU6cGFzc3dvcmQ=",
+			},
+		},
+		SomeConfigField: 123,
+	}
+	jsonStr, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to marshal config: %v", err)
+	}
+	if err := os.WriteFile(configPath, jsonStr, 0666); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	for _, opts := range []StoreOptions{
+		{},
+		{DetectDefaultNativeStore: true},
+	} {
+		ds, err := NewStore(configPath, opts)
+		if err != nil {
+			t.Fatal("NewStore() error =", err)
+		}
+		ctx := context.Background()
+		for _, serverAddr := range []string{"test.example.com", "other.example.com"} {
+			if _, err := ds.Get(ctx, serverAddr); err != nil {
+				t.Fatal("DynamicStore.Get() error =", err)
+			}
+		}
+
+		// NewStore() and Get() should leave the config file untouched
+		got, err := os.ReadFile(configPath)
+		if err != nil {
+			t.Fatalf("failed to read config file: %v", err)
+		}
+		if !bytes.Equal(got, jsonStr) {
+			t.Errorf("config file content = %s, want %s", got, jsonStr)
+		}
+	}
+}
+
+func Test_DynamicStore_emptyCredsStore_DetectDefaultNativeStore(t *testing.T) {
+	helperPath := installDefaultTestHelper(t)
+
+	// prepare test content
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.json")
+	jsonStr := []byte(`{"credsStore":""}`)
+	if err := os.WriteFile(configPath, jsonStr, 0666); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	opts := StoreOptions{
+		AllowPlaintextPut:        true,
+		DetectDefaultNativeStore: true,
+	}
+	ds, err := NewStore(configPath, opts)
+	if err != nil {
+		t.Fatal("NewStore() error =", err)
+	}
+
+	// NewStore() and Get() should leave the config file untouched
+	serverAddr := "test.example.com"
+	ctx := context.Background()
+	if _, err := ds.Get(ctx, serverAddr); err != nil {
+		t.Fatal("DynamicStore.Get() error =", err)
+	}
+	got, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("failed to read config file: %v", err)
+	}
+	if !bytes.Equal(got, jsonStr) {
+		t.Errorf("config file content = %s, want %s", got, jsonStr)
+	}
+
+	// Put() should save into the config file instead of the native store
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := ds.Put(ctx, serverAddr, cred); err != nil {
+		t.Fatal("DynamicStore.Put() error =", err)
+	}
+	if list := listTestHelper(t, helperPath); len(list) != 0 {
+		t.Errorf("native store contains %d credentials, want 0", len(list))
+	}
+	var gotCfg configtest.Config
+	if got, err = os.ReadFile(configPath); err != nil {
+		t.Fatalf("failed to read config file: %v", err)
+	}
+	if err := json.Unmarshal(got, &gotCfg); err != nil {
+		t.Fatalf("failed to decode config file: %v", err)
+	}
+	if gotCfg.CredentialsStore != "" {
+		t.Errorf("Decoded config.credsStore = %v, want empty", gotCfg.CredentialsStore)
+	}
+	if want := "dXNlcm5hbWU6cGFzc3dvcmQ="; gotCfg.AuthConfigs[serverAddr].Auth != want {
+		t.Errorf("Decoded config.auths[%s].auth = %v, want %v", serverAddr, gotCfg.AuthConfigs[serverAddr].Auth, want)
+	}
+}
+
 func Test_DynamicStore_fileStore_AllowPlainTextPut(t *testing.T) {
 	// prepare test content
 	tempDir := t.TempDir()
@@ -697,6 +873,155 @@ func Test_storeWithFallbacks_Delete_throwError(t *testing.T) {
 	}
 }
 
+func Test_storeWithFallbacks_PropagatePut(t *testing.T) {
+	primaryStore := &testStore{}
+	fallbackStore := &testStore{}
+	opts := FallbackOptions{
+		PropagatePut: true,
+	}
+	sf := NewStoreWithFallbacksOptions(primaryStore, []Store{fallbackStore}, opts)
+	ctx := context.Background()
+
+	server := "example.registry.com"
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	// test Put()
+	if err := sf.Put(ctx, server, cred); err != nil {
+		t.Fatal("storeWithFallbacks.Put() error =", err)
+	}
+	want := map[string]auth.Credential{server: cred}
+	if !reflect.DeepEqual(primaryStore.storage, want) {
+		t.Errorf("primaryStore.storage = %v, want %v", primaryStore.storage, want)
+	}
+	if !reflect.DeepEqual(fallbackStore.storage, want) {
+		t.Errorf("fallbackStore.storage = %v, want %v", fallbackStore.storage, want)
+	}
+
+	// test Delete()
+	if err := sf.Delete(ctx, server); err != nil {
+		t.Fatal("storeWithFallbacks.Delete() error =", err)
+	}
+	want = map[string]auth.Credential{}
+	if !reflect.DeepEqual(primaryStore.storage, want) {
+		t.Errorf("primaryStore.storage = %v, want %v", primaryStore.storage, want)
+	}
+	if !reflect.DeepEqual(fallbackStore.storage, want) {
+		t.Errorf("fallbackStore.storage = %v, want %v", fallbackStore.storage, want)
+	}
+}
+
+func Test_storeWithFallbacks_PropagatePut_throwError(t *testing.T) {
+	badStore := &badStore{}
+	goodStore := &testStore{}
+	opts := FallbackOptions{
+		PropagatePut: true,
+	}
+	sf := NewStoreWithFallbacksOptions(badStore, []Store{goodStore}, opts)
+	ctx := context.Background()
+
+	server := "example.registry.com"
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	// test Put(): should throw error, but still save into the good store
+	err := sf.Put(ctx, server, cred)
+	if wantErr := errBadStore; !errors.Is(err, wantErr) {
+		t.Errorf("storeWithFallback.Put() error = %v, wantErr %v", err, wantErr)
+	}
+	if want := map[string]auth.Credential{server: cred}; !reflect.DeepEqual(goodStore.storage, want) {
+		t.Errorf("goodStore.storage = %v, want %v", goodStore.storage, want)
+	}
+
+	// test Delete(): should throw error, but still delete from the good store
+	err = sf.Delete(ctx, server)
+	if wantErr := errBadStore; !errors.Is(err, wantErr) {
+		t.Errorf("storeWithFallback.Delete() error = %v, wantErr %v", err, wantErr)
+	}
+	if want := map[string]auth.Credential{}; !reflect.DeepEqual(goodStore.storage, want) {
+		t.Errorf("goodStore.storage = %v, want %v", goodStore.storage, want)
+	}
+}
+
+// errorStore is a store whose Get() fails with err, used for testing purpose.
+type errorStore struct {
+	testStore
+	err error
+}
+
+func (es *errorStore) Get(context.Context, string) (auth.Credential, error) {
+	return auth.EmptyCredential, es.err
+}
+
+func Test_storeWithFallbacks_ContinueOnError(t *testing.T) {
+	ctx := context.Background()
+	server := "example.registry.com"
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	goodStore := &testStore{}
+	if err := goodStore.Put(ctx, server, cred); err != nil {
+		t.Fatal("testStore.Put() error =", err)
+	}
+	opts := FallbackOptions{
+		ContinueOnError: true,
+	}
+
+	// test Get(): should skip the failing store and find the credentials
+	sf := NewStoreWithFallbacksOptions(&badStore{}, []Store{goodStore}, opts)
+	got, err := sf.Get(ctx, server)
+	if err != nil {
+		t.Fatal("storeWithFallbacks.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("storeWithFallbacks.Get() = %v, want %v", got, cred)
+	}
+
+	// test Get(): should return the errors of all the failing stores
+	errOther := errors.New("other error")
+	otherStore := &errorStore{err: errOther}
+	sf = NewStoreWithFallbacksOptions(&badStore{}, []Store{&testStore{}, otherStore}, opts)
+	got, err = sf.Get(ctx, server)
+	if got != auth.EmptyCredential {
+		t.Errorf("storeWithFallbacks.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+	if !errors.Is(err, errBadStore) || !errors.Is(err, errOther) {
+		t.Fatalf("storeWithFallbacks.Get() error = %v, wantErr %v and %v", err, errBadStore, errOther)
+	}
+	if want := "store 0: " + errBadStore.Error() + "\nstore 2: " + errOther.Error(); err.Error() != want {
+		t.Errorf("storeWithFallbacks.Get() error = %q, want %q", err.Error(), want)
+	}
+
+	// test Get(): should not return an error if no store fails
+	sf = NewStoreWithFallbacksOptions(&testStore{}, []Store{&testStore{}}, opts)
+	if got, err = sf.Get(ctx, server); err != nil {
+		t.Fatal("storeWithFallbacks.Get() error =", err)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("storeWithFallbacks.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+}
+
+func Test_joinErrors(t *testing.T) {
+	if err := joinErrors(nil, nil); err != nil {
+		t.Errorf("joinErrors() = %v, want nil", err)
+	}
+	errOther := errors.New("other error")
+	err := joinErrors(errBadStore, nil, errOther)
+	if !errors.Is(err, errBadStore) {
+		t.Errorf("joinErrors() = %v, want to match %v", err, errBadStore)
+	}
+	if !errors.Is(err, errOther) {
+		t.Errorf("joinErrors() = %v, want to match %v", err, errOther)
+	}
+	if want := errBadStore.Error() + "\n" + errOther.Error(); err.Error() != want {
+		t.Errorf("joinErrors().Error() = %q, want %q", err.Error(), want)
+	}
+}
+
 func TestNewStoreFromDocker(t *testing.T) {
 	// prepare test content
 	tempDir := t.TempDir()
@@ -774,3 +1099,36 @@ func TestNewStoreFromDocker(t *testing.T) {
 		t.Errorf("DynamicStore.Get() = %v, want %v", got, want)
 	}
 }
+
+func TestNewStoreFromDocker_defaultConfigPath(t *testing.T) {
+	// prepare test content
+	tempDir := t.TempDir()
+	t.Setenv("DOCKER_CONFIG", filepath.Join(tempDir, "docker"))
+	configPath := filepath.Join(tempDir, "custom", "config.json")
+	SetDefaultConfigPath(configPath)
+	t.Cleanup(func() { SetDefaultConfigPath("") })
+
+	if got := DefaultConfigPath(); got != configPath {
+		t.Errorf("DefaultConfigPath() = %v, want %v", got, configPath)
+	}
+	ds, err := NewStoreFromDocker(StoreOptions{})
+	if err != nil {
+		t.Fatal("NewStoreFromDocker() error =", err)
+	}
+	if got := ds.ConfigPath(); got != configPath {
+		t.Errorf("DynamicStore.ConfigPath() = %v, want %v", got, configPath)
+	}
+
+	// reset to the default docker config path
+	SetDefaultConfigPath("")
+	if got := DefaultConfigPath(); got != "" {
+		t.Errorf("DefaultConfigPath() = %v, want empty", got)
+	}
+	ds, err = NewStoreFromDocker(StoreOptions{})
+	if err != nil {
+		t.Fatal("NewStoreFromDocker() error =", err)
+	}
+	if want := filepath.Join(tempDir, "docker", "config.json"); ds.ConfigPath() != want {
+		t.Errorf("DynamicStore.ConfigPath() = %v, want %v", ds.ConfigPath(), want)
+	}
+}
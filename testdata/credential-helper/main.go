@@ -0,0 +1,134 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command credential-helper is a minimal credential helper following the
+// docker credential helper protocol, used for testing purpose. It keeps the
+// credentials in the JSON file specified by the $TEST_HELPER_STORE
+// environment variable. If the $TEST_HELPER_STDERR environment variable is
+// set, the responses are written to stderr instead of stdout, like some
+// misbehaving helpers do.
+//
+// Reference: https://docs.docker.com/engine/reference/commandline/login/#credential-helper-protocol
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const errCredentialsNotFoundMessage = "credentials not found in native keychain"
+
+// credentials is the payload of the "store" and "get" actions.
+type credentials struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+func main() {
+	if len(os.Args) != 2 {
+		fail(errors.New("usage: docker-credential-test <store|get|erase|list>"))
+	}
+	out := os.Stdout
+	if os.Getenv("TEST_HELPER_STDERR") != "" {
+		out = os.Stderr
+	}
+	if err := run(os.Args[1], os.Stdin, out); err != nil {
+		fail(err)
+	}
+}
+
+// fail reports err on stdout, as expected by the protocol, and exits.
+func fail(err error) {
+	fmt.Fprintln(os.Stdout, err)
+	os.Exit(1)
+}
+
+func run(action string, in io.Reader, out io.Writer) error {
+	storePath := os.Getenv("TEST_HELPER_STORE")
+	store, err := load(storePath)
+	if err != nil {
+		return err
+	}
+	switch action {
+	case "store":
+		var cred credentials
+		if err := json.NewDecoder(in).Decode(&cred); err != nil {
+			return err
+		}
+		store[cred.ServerURL] = cred
+		return save(storePath, store)
+	case "get":
+		serverURL, err := readServerURL(in)
+		if err != nil {
+			return err
+		}
+		cred, ok := store[serverURL]
+		if !ok {
+			return errors.New(errCredentialsNotFoundMessage)
+		}
+		return json.NewEncoder(out).Encode(cred)
+	case "erase":
+		serverURL, err := readServerURL(in)
+		if err != nil {
+			return err
+		}
+		delete(store, serverURL)
+		return save(storePath, store)
+	case "list":
+		list := make(map[string]string, len(store))
+		for serverURL, cred := range store {
+			list[serverURL] = cred.Username
+		}
+		return json.NewEncoder(out).Encode(list)
+	default:
+		return fmt.Errorf("unknown action: %s", action)
+	}
+}
+
+func readServerURL(in io.Reader) (string, error) {
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+func load(path string) (map[string]credentials, error) {
+	store := make(map[string]credentials)
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return store, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &store); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+func save(path string, store map[string]credentials) error {
+	b, err := json.Marshal(store)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0600)
+}
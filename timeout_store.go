@@ -0,0 +1,119 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// timeoutStore is a store bounding the duration of every operation.
+type timeoutStore struct {
+	inner   Store
+	timeout time.Duration
+}
+
+// NewTimeoutStore returns a store that applies timeout to the context of
+// every Get(), Put() and Delete() call on the inner store. As credential
+// helpers are executed with the context, wrapping a store created by
+// NewNativeStore or NewStore kills helpers that hang, for example reading
+// stdin, even when callers pass context.Background(). When the timeout
+// fires, the returned error matches context.DeadlineExceeded, wraps the
+// error returned by the inner store and states which operation timed out. If
+// timeout is not positive, the inner store is returned as is.
+func NewTimeoutStore(inner Store, timeout time.Duration) Store {
+	if timeout <= 0 {
+		return inner
+	}
+	return &timeoutStore{
+		inner:   inner,
+		timeout: timeout,
+	}
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (ts *timeoutStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	ctx, cancel := context.WithTimeout(ctx, ts.timeout)
+	defer cancel()
+	cred, err := ts.inner.Get(ctx, serverAddress)
+	if err != nil {
+		return auth.EmptyCredential, ts.wrapError(ctx, "get", serverAddress, err)
+	}
+	return cred, nil
+}
+
+// Put saves credentials into the store for the given server address.
+func (ts *timeoutStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	ctx, cancel := context.WithTimeout(ctx, ts.timeout)
+	defer cancel()
+	if err := ts.inner.Put(ctx, serverAddress, cred); err != nil {
+		return ts.wrapError(ctx, "put", serverAddress, err)
+	}
+	return nil
+}
+
+// Delete removes credentials from the store for the given server address.
+func (ts *timeoutStore) Delete(ctx context.Context, serverAddress string) error {
+	ctx, cancel := context.WithTimeout(ctx, ts.timeout)
+	defer cancel()
+	if err := ts.inner.Delete(ctx, serverAddress); err != nil {
+		return ts.wrapError(ctx, "delete", serverAddress, err)
+	}
+	return nil
+}
+
+// wrapError states in err that the operation timed out, if ctx expired.
+func (ts *timeoutStore) wrapError(ctx context.Context, op string, serverAddress string, err error) error {
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	return &timeoutError{
+		op:            op,
+		serverAddress: serverAddress,
+		timeout:       ts.timeout,
+		err:           err,
+	}
+}
+
+// timeoutError is the error returned by a timeout store when an operation
+// times out. It matches context.DeadlineExceeded and wraps the error returned
+// by the inner store.
+type timeoutError struct {
+	op            string
+	serverAddress string
+	timeout       time.Duration
+	err           error
+}
+
+// Error returns the message of the timed out operation.
+func (e *timeoutError) Error() string {
+	return fmt.Sprintf("%v: %s credentials for %s timed out after %v: %v",
+		context.DeadlineExceeded, e.op, e.serverAddress, e.timeout, e.err)
+}
+
+// Unwrap returns the error returned by the inner store.
+func (e *timeoutError) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether target is context.DeadlineExceeded.
+func (e *timeoutError) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
@@ -0,0 +1,148 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// hangingStore is a store whose operations block until the context is done,
+// used for testing purpose.
+type hangingStore struct{}
+
+func (hangingStore) Get(ctx context.Context, _ string) (auth.Credential, error) {
+	<-ctx.Done()
+	return auth.EmptyCredential, ctx.Err()
+}
+
+func (hangingStore) Put(ctx context.Context, _ string, _ auth.Credential) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (hangingStore) Delete(ctx context.Context, _ string) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestTimeoutStore_timeout(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTimeoutStore(hangingStore{}, 10*time.Millisecond)
+	serverAddress := "registry.example.com"
+
+	_, err := ts.Get(ctx, serverAddress)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("timeoutStore.Get() error = %v, wantErr %v", err, context.DeadlineExceeded)
+	}
+	if !strings.Contains(err.Error(), "get credentials for registry.example.com timed out") {
+		t.Errorf("timeoutStore.Get() error = %v, want the timed out operation", err)
+	}
+	if err := ts.Put(ctx, serverAddress, auth.EmptyCredential); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("timeoutStore.Put() error = %v, wantErr %v", err, context.DeadlineExceeded)
+	}
+	if err := ts.Delete(ctx, serverAddress); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("timeoutStore.Delete() error = %v, wantErr %v", err, context.DeadlineExceeded)
+	}
+}
+
+// errKilledStore is the error returned by killedStore.
+var errKilledStore = errors.New("helper killed")
+
+// killedStore is a store whose Get() blocks until the context is done, then
+// returns errKilledStore, used for testing purpose.
+type killedStore struct {
+	Store
+}
+
+func (killedStore) Get(ctx context.Context, _ string) (auth.Credential, error) {
+	<-ctx.Done()
+	return auth.EmptyCredential, errKilledStore
+}
+
+func TestTimeoutStore_timeout_innerError(t *testing.T) {
+	ts := NewTimeoutStore(killedStore{}, 10*time.Millisecond)
+	_, err := ts.Get(context.Background(), "registry.example.com")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("timeoutStore.Get() error = %v, wantErr %v", err, context.DeadlineExceeded)
+	}
+	if !errors.Is(err, errKilledStore) {
+		t.Errorf("timeoutStore.Get() error = %v, wantErr %v", err, errKilledStore)
+	}
+}
+
+func TestTimeoutStore_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ts := NewTimeoutStore(hangingStore{}, time.Minute)
+	_, err := ts.Get(ctx, "registry.example.com")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("timeoutStore.Get() error = %v, wantErr %v", err, context.Canceled)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("timeoutStore.Get() error = %v, want not %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestTimeoutStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTimeoutStore(NewMemoryStore(), time.Minute)
+	serverAddress := "registry.example.com"
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := ts.Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("timeoutStore.Put() error =", err)
+	}
+	got, err := ts.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("timeoutStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("timeoutStore.Get() = %v, want %v", got, cred)
+	}
+	if err := ts.Delete(ctx, serverAddress); err != nil {
+		t.Fatal("timeoutStore.Delete() error =", err)
+	}
+}
+
+func TestTimeoutStore_noTimeout(t *testing.T) {
+	inner := NewMemoryStore()
+	if got := NewTimeoutStore(inner, 0); got != inner {
+		t.Errorf("NewTimeoutStore() = %v, want the inner store", got)
+	}
+}
+
+func TestTimeoutStore_badStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTimeoutStore(&badStore{}, time.Minute)
+	serverAddress := "registry.example.com"
+	if _, err := ts.Get(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("timeoutStore.Get() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := ts.Put(ctx, serverAddress, auth.EmptyCredential); !errors.Is(err, errBadStore) {
+		t.Errorf("timeoutStore.Put() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := ts.Delete(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("timeoutStore.Delete() error = %v, wantErr %v", err, errBadStore)
+	}
+}
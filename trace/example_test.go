@@ -56,7 +56,7 @@ func Example() {
 	if err != nil {
 		panic(err)
 	}
-	fmt.Println(cred)
+	fmt.Println(credentials.Redact(cred))
 
 	err = store.Delete(ctx, "localhost:5000")
 	if err != nil {
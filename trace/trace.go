@@ -21,6 +21,8 @@ package trace
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"oras.land/oras-go/v2/registry/remote/credentials/trace"
 )
@@ -54,3 +56,47 @@ func ContextExecutableTrace(ctx context.Context) *ExecutableTrace {
 func WithExecutableTrace(ctx context.Context, et *ExecutableTrace) context.Context {
 	return trace.WithExecutableTrace(ctx, et)
 }
+
+// WithExecutableDuration takes a Context and a callback, and returns a Context
+// with an ExecutableTrace added as a Value, which calls onExec with the
+// duration of each execution of a credential helper executable once it
+// completes. The duration covers both spawning the process and the work done
+// by the helper. As with [WithExecutableTrace], previously added hooks are
+// preserved.
+//
+// Concurrent executions of the same executable and action sharing the
+// context are matched to their start in order, so their reported durations
+// may be interchanged.
+func WithExecutableDuration(ctx context.Context, onExec func(executableName string, action string, dur time.Duration, err error)) context.Context {
+	type execKey struct {
+		executableName string
+		action         string
+	}
+	var lock sync.Mutex
+	starts := make(map[execKey][]time.Time)
+	return WithExecutableTrace(ctx, &ExecutableTrace{
+		ExecuteStart: func(executableName string, action string) {
+			key := execKey{executableName, action}
+			lock.Lock()
+			defer lock.Unlock()
+			starts[key] = append(starts[key], time.Now())
+		},
+		ExecuteDone: func(executableName string, action string, err error) {
+			key := execKey{executableName, action}
+			lock.Lock()
+			pending := starts[key]
+			if len(pending) == 0 {
+				lock.Unlock()
+				return
+			}
+			start := pending[0]
+			if len(pending) == 1 {
+				delete(starts, key)
+			} else {
+				starts[key] = pending[1:]
+			}
+			lock.Unlock()
+			onExec(executableName, action, time.Since(start), err)
+		},
+	})
+}
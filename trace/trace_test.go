@@ -0,0 +1,72 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithExecutableDuration(t *testing.T) {
+	type execRecord struct {
+		executableName string
+		action         string
+		dur            time.Duration
+		err            error
+	}
+	var records []execRecord
+	var started []string
+	ctx := WithExecutableTrace(context.Background(), &ExecutableTrace{
+		ExecuteStart: func(executableName string, action string) {
+			started = append(started, action)
+		},
+	})
+	ctx = WithExecutableDuration(ctx, func(executableName string, action string, dur time.Duration, err error) {
+		records = append(records, execRecord{executableName, action, dur, err})
+	})
+
+	et := ContextExecutableTrace(ctx)
+	et.ExecuteStart("docker-credential-test", "get")
+	time.Sleep(10 * time.Millisecond)
+	errExec := errors.New("exec error")
+	et.ExecuteDone("docker-credential-test", "get", errExec)
+
+	if len(records) != 1 {
+		t.Fatalf("onExec called %d times, want 1", len(records))
+	}
+	got := records[0]
+	if got.executableName != "docker-credential-test" || got.action != "get" {
+		t.Errorf("onExec() executable = %s, action = %s, want docker-credential-test, get", got.executableName, got.action)
+	}
+	if got.dur < 10*time.Millisecond {
+		t.Errorf("onExec() dur = %v, want at least %v", got.dur, 10*time.Millisecond)
+	}
+	if !errors.Is(got.err, errExec) {
+		t.Errorf("onExec() err = %v, want %v", got.err, errExec)
+	}
+	// previously added hooks should be preserved
+	if len(started) != 1 || started[0] != "get" {
+		t.Errorf("previous ExecuteStart() calls = %v, want [get]", started)
+	}
+
+	// unmatched completion should not be reported
+	et.ExecuteDone("docker-credential-test", "store", nil)
+	if len(records) != 1 {
+		t.Errorf("onExec called %d times, want 1", len(records))
+	}
+}
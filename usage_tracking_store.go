@@ -0,0 +1,65 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// usageTrackingStore is a store reporting the credential lookups.
+type usageTrackingStore struct {
+	inner Store
+	onGet func(serverAddress string, found bool)
+}
+
+// NewUsageTrackingStore returns a store calling onGet after every successful
+// Get() on the inner store, with the server address and whether non-empty
+// credentials were found. The credentials themselves are never passed to
+// onGet. It allows tools to track which registries are in use, for example
+// to suggest pruning stale logins.
+//   - Get() does not call onGet if the inner store fails.
+//   - Put() and Delete() are passed to the inner store as is.
+//
+// onGet is called synchronously, so it should return quickly and be safe for
+// concurrent use if the store is.
+func NewUsageTrackingStore(inner Store, onGet func(serverAddress string, found bool)) Store {
+	return &usageTrackingStore{
+		inner: inner,
+		onGet: onGet,
+	}
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (us *usageTrackingStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	cred, err := us.inner.Get(ctx, serverAddress)
+	if err != nil {
+		return auth.EmptyCredential, err
+	}
+	us.onGet(serverAddress, cred != auth.EmptyCredential)
+	return cred, nil
+}
+
+// Put saves credentials into the store for the given server address.
+func (us *usageTrackingStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	return us.inner.Put(ctx, serverAddress, cred)
+}
+
+// Delete removes credentials from the store for the given server address.
+func (us *usageTrackingStore) Delete(ctx context.Context, serverAddress string) error {
+	return us.inner.Delete(ctx, serverAddress)
+}
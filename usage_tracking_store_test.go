@@ -0,0 +1,83 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// usage is a call to the onGet hook of a usage tracking store.
+type usage struct {
+	serverAddress string
+	found         bool
+}
+
+func TestUsageTrackingStore(t *testing.T) {
+	ctx := context.Background()
+	var usages []usage
+	us := NewUsageTrackingStore(NewMemoryStore(), func(serverAddress string, found bool) {
+		usages = append(usages, usage{serverAddress: serverAddress, found: found})
+	})
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := us.Put(ctx, "registry1.example.com", cred); err != nil {
+		t.Fatal("usageTrackingStore.Put() error =", err)
+	}
+	for _, serverAddress := range []string{"registry1.example.com", "registry2.example.com"} {
+		if _, err := us.Get(ctx, serverAddress); err != nil {
+			t.Fatal("usageTrackingStore.Get() error =", err)
+		}
+	}
+	if err := us.Delete(ctx, "registry1.example.com"); err != nil {
+		t.Fatal("usageTrackingStore.Delete() error =", err)
+	}
+
+	want := []usage{
+		{serverAddress: "registry1.example.com", found: true},
+		{serverAddress: "registry2.example.com", found: false},
+	}
+	if !reflect.DeepEqual(usages, want) {
+		t.Errorf("onGet calls = %v, want %v", usages, want)
+	}
+}
+
+func TestUsageTrackingStore_badStore(t *testing.T) {
+	ctx := context.Background()
+	var called bool
+	us := NewUsageTrackingStore(&badStore{}, func(string, bool) {
+		called = true
+	})
+	serverAddress := "registry.example.com"
+	if _, err := us.Get(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("usageTrackingStore.Get() error = %v, wantErr %v", err, errBadStore)
+	}
+	if called {
+		t.Error("onGet called on a failed Get()")
+	}
+	if err := us.Put(ctx, serverAddress, auth.EmptyCredential); !errors.Is(err, errBadStore) {
+		t.Errorf("usageTrackingStore.Put() error = %v, wantErr %v", err, errBadStore)
+	}
+	if err := us.Delete(ctx, serverAddress); !errors.Is(err, errBadStore) {
+		t.Errorf("usageTrackingStore.Delete() error = %v, wantErr %v", err, errBadStore)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// ErrInvalidCredentialEncoding is returned by a UTF-8 validating store when a
+// field of a credential is not valid UTF-8.
+var ErrInvalidCredentialEncoding = errors.New("invalid credential encoding")
+
+// utf8ValidatingStore is a store that rejects credentials not encoded in
+// UTF-8.
+type utf8ValidatingStore struct {
+	inner Store
+}
+
+// NewUTF8ValidatingStore returns a store that checks that the Username,
+// Password, RefreshToken and AccessToken fields of the credentials going
+// through the inner store are valid UTF-8, as a non-UTF-8 secret, decoded
+// from a config file or returned by a credential helper, may break the
+// construction of HTTP headers. Stores not wrapped keep the bytes as is.
+//   - Get() returns ErrInvalidCredentialEncoding if the credential returned
+//     by the inner store is not valid UTF-8.
+//   - Put() returns ErrInvalidCredentialEncoding without calling the inner
+//     store if the given credential is not valid UTF-8.
+//   - Delete() deletes the credential from the inner store.
+//
+// The errors name the invalid field, never its content.
+func NewUTF8ValidatingStore(inner Store) Store {
+	return &utf8ValidatingStore{inner: inner}
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (us *utf8ValidatingStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	cred, err := us.inner.Get(ctx, serverAddress)
+	if err != nil {
+		return auth.EmptyCredential, err
+	}
+	if err := checkUTF8(serverAddress, cred); err != nil {
+		return auth.EmptyCredential, err
+	}
+	return cred, nil
+}
+
+// Put saves credentials into the store for the given server address.
+func (us *utf8ValidatingStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	if err := checkUTF8(serverAddress, cred); err != nil {
+		return err
+	}
+	return us.inner.Put(ctx, serverAddress, cred)
+}
+
+// Delete removes credentials from the store for the given server address.
+func (us *utf8ValidatingStore) Delete(ctx context.Context, serverAddress string) error {
+	return us.inner.Delete(ctx, serverAddress)
+}
+
+// checkUTF8 returns ErrInvalidCredentialEncoding if a field of cred is not
+// valid UTF-8.
+func checkUTF8(serverAddress string, cred auth.Credential) error {
+	for _, field := range []struct {
+		name  string
+		value string
+	}{
+		{"username", cred.Username},
+		{"password", cred.Password},
+		{"refresh token", cred.RefreshToken},
+		{"access token", cred.AccessToken},
+	} {
+		if !utf8.ValidString(field.value) {
+			return fmt.Errorf("%w: the %s of the credential for %s is not valid UTF-8",
+				ErrInvalidCredentialEncoding, field.name, serverAddress)
+		}
+	}
+	return nil
+}
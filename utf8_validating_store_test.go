@@ -0,0 +1,119 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestUTF8ValidatingStore_Get(t *testing.T) {
+	ctx := context.Background()
+	ms := NewMemoryStore()
+	us := NewUTF8ValidatingStore(ms)
+	cred := auth.Credential{
+		Username: "username",
+		Password: "pässword",
+	}
+	if err := ms.Put(ctx, "valid.example.com", cred); err != nil {
+		t.Fatal("MemoryStore.Put() error =", err)
+	}
+	badCred := auth.Credential{
+		Username: "username",
+		Password: "pass\xffword",
+	}
+	if err := ms.Put(ctx, "invalid.example.com", badCred); err != nil {
+		t.Fatal("MemoryStore.Put() error =", err)
+	}
+
+	// valid UTF-8
+	got, err := us.Get(ctx, "valid.example.com")
+	if err != nil {
+		t.Fatal("utf8ValidatingStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("utf8ValidatingStore.Get() = %v, want %v", got, cred)
+	}
+
+	// invalid UTF-8
+	got, err = us.Get(ctx, "invalid.example.com")
+	if !errors.Is(err, ErrInvalidCredentialEncoding) {
+		t.Fatalf("utf8ValidatingStore.Get() error = %v, wantErr %v", err, ErrInvalidCredentialEncoding)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("utf8ValidatingStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "password") || strings.Contains(msg, badCred.Password) {
+		t.Errorf("utf8ValidatingStore.Get() error = %v, want the invalid field without its content", err)
+	}
+
+	// inner store error
+	us = NewUTF8ValidatingStore(&badStore{})
+	if _, err := us.Get(ctx, "valid.example.com"); !errors.Is(err, errBadStore) {
+		t.Errorf("utf8ValidatingStore.Get() error = %v, wantErr %v", err, errBadStore)
+	}
+}
+
+func TestUTF8ValidatingStore_Put(t *testing.T) {
+	ctx := context.Background()
+	ms := NewMemoryStore()
+	us := NewUTF8ValidatingStore(ms)
+	serverAddress := "registry.example.com"
+
+	// invalid UTF-8
+	badCred := auth.Credential{
+		RefreshToken: "identity\xfe_token",
+	}
+	if err := us.Put(ctx, serverAddress, badCred); !errors.Is(err, ErrInvalidCredentialEncoding) {
+		t.Errorf("utf8ValidatingStore.Put() error = %v, wantErr %v", err, ErrInvalidCredentialEncoding)
+	}
+	got, err := ms.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("MemoryStore.Get() error =", err)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("MemoryStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+
+	// valid UTF-8
+	cred := auth.Credential{
+		RefreshToken: "identity_token",
+	}
+	if err := us.Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("utf8ValidatingStore.Put() error =", err)
+	}
+	if got, err = ms.Get(ctx, serverAddress); err != nil {
+		t.Fatal("MemoryStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("MemoryStore.Get() = %v, want %v", got, cred)
+	}
+
+	// test delete
+	if err := us.Delete(ctx, serverAddress); err != nil {
+		t.Fatal("utf8ValidatingStore.Delete() error =", err)
+	}
+	if got, err = ms.Get(ctx, serverAddress); err != nil {
+		t.Fatal("MemoryStore.Get() error =", err)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("MemoryStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+}
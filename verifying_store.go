@@ -0,0 +1,112 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// ErrCredentialVerificationFailed is returned by Put() of a verifying store
+// when the credential fails the verification.
+var ErrCredentialVerificationFailed = errors.New("credential verification failed")
+
+// verificationError is the error returned by Put() of a verifying store when
+// the credential fails the verification. It matches
+// ErrCredentialVerificationFailed and wraps the error returned by the verify
+// function.
+type verificationError struct {
+	serverAddress string
+	err           error
+}
+
+// Error returns the message of the verification failure.
+func (e *verificationError) Error() string {
+	return fmt.Sprintf("%v: %s: %v", ErrCredentialVerificationFailed, e.serverAddress, e.err)
+}
+
+// Unwrap returns the error returned by the verify function.
+func (e *verificationError) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether target is ErrCredentialVerificationFailed.
+func (e *verificationError) Is(target error) bool {
+	return target == ErrCredentialVerificationFailed
+}
+
+// verifyingStore is a store verifying credentials before saving them.
+type verifyingStore struct {
+	inner  Store
+	verify func(ctx context.Context, serverAddress string, cred auth.Credential) error
+}
+
+// NewVerifyingStore returns a store whose Put() calls verify and refuses to
+// save credentials failing the verification, which prevents saving a
+// mistyped password that would then fail on every subsequent request.
+//   - Put() returns an error matching ErrCredentialVerificationFailed and
+//     wrapping the error returned by verify, without calling the inner store
+//     if verify fails. Errors returned by the inner store are returned as
+//     is, so verification failures are distinguishable from storage
+//     failures.
+//   - Get() and Delete() are passed to the inner store as is.
+//
+// [RegistryVerifier] provides a verify function authenticating against a
+// registry.
+func NewVerifyingStore(inner Store, verify func(ctx context.Context, serverAddress string, cred auth.Credential) error) Store {
+	return &verifyingStore{
+		inner:  inner,
+		verify: verify,
+	}
+}
+
+// Get retrieves credentials from the store for the given server address.
+func (vs *verifyingStore) Get(ctx context.Context, serverAddress string) (auth.Credential, error) {
+	return vs.inner.Get(ctx, serverAddress)
+}
+
+// Put saves credentials into the store for the given server address.
+func (vs *verifyingStore) Put(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	if err := vs.verify(ctx, serverAddress, cred); err != nil {
+		return &verificationError{
+			serverAddress: serverAddress,
+			err:           err,
+		}
+	}
+	return vs.inner.Put(ctx, serverAddress, cred)
+}
+
+// Delete removes credentials from the store for the given server address.
+func (vs *verifyingStore) Delete(ctx context.Context, serverAddress string) error {
+	return vs.inner.Delete(ctx, serverAddress)
+}
+
+// RegistryVerifier returns a verify function for [NewVerifyingStore] that
+// validates credentials by pinging reg with them, regardless of the server
+// address they are saved under. The target registry's client should be nil
+// or of type *auth.Client. Like [Login], the verification uses a client local
+// to the function and will not modify the original client of the registry.
+func RegistryVerifier(reg *remote.Registry) func(ctx context.Context, serverAddress string, cred auth.Credential) error {
+	return func(ctx context.Context, _ string, cred auth.Credential) error {
+		return pingWithCredential(ctx, reg, cred, func(ctx context.Context, reg *remote.Registry) error {
+			return reg.Ping(ctx)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestVerifyingStore_Put(t *testing.T) {
+	errInvalid := errors.New("invalid credential")
+	verify := func(ctx context.Context, serverAddress string, cred auth.Credential) error {
+		if cred.Password != "password" {
+			return errInvalid
+		}
+		return nil
+	}
+	ctx := context.Background()
+	inner := NewMemoryStore()
+	vs := NewVerifyingStore(inner, verify)
+	serverAddress := "registry.example.com"
+
+	// test put invalid credential
+	badCred := auth.Credential{
+		Username: "username",
+		Password: "typo",
+	}
+	if err := vs.Put(ctx, serverAddress, badCred); !errors.Is(err, ErrCredentialVerificationFailed) {
+		t.Fatalf("verifyingStore.Put() error = %v, wantErr %v", err, ErrCredentialVerificationFailed)
+	}
+	if err := vs.Put(ctx, serverAddress, badCred); !errors.Is(err, errInvalid) {
+		t.Fatalf("verifyingStore.Put() error = %v, wantErr %v", err, errInvalid)
+	}
+	got, err := inner.Get(ctx, serverAddress)
+	if err != nil {
+		t.Fatal("MemoryStore.Get() error =", err)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("MemoryStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+
+	// test put valid credential
+	cred := auth.Credential{
+		Username: "username",
+		Password: "password",
+	}
+	if err := vs.Put(ctx, serverAddress, cred); err != nil {
+		t.Fatal("verifyingStore.Put() error =", err)
+	}
+	if got, err = vs.Get(ctx, serverAddress); err != nil {
+		t.Fatal("verifyingStore.Get() error =", err)
+	}
+	if got != cred {
+		t.Errorf("verifyingStore.Get() = %v, want %v", got, cred)
+	}
+
+	// test delete
+	if err := vs.Delete(ctx, serverAddress); err != nil {
+		t.Fatal("verifyingStore.Delete() error =", err)
+	}
+	if got, err = vs.Get(ctx, serverAddress); err != nil {
+		t.Fatal("verifyingStore.Get() error =", err)
+	}
+	if got != auth.EmptyCredential {
+		t.Errorf("verifyingStore.Get() = %v, want %v", got, auth.EmptyCredential)
+	}
+}
+
+func TestVerifyingStore_badStore(t *testing.T) {
+	ctx := context.Background()
+	vs := NewVerifyingStore(&badStore{}, func(context.Context, string, auth.Credential) error {
+		return nil
+	})
+	serverAddress := "registry.example.com"
+	err := vs.Put(ctx, serverAddress, auth.EmptyCredential)
+	if !errors.Is(err, errBadStore) {
+		t.Errorf("verifyingStore.Put() error = %v, wantErr %v", err, errBadStore)
+	}
+	// storage failures should not be reported as verification failures
+	if errors.Is(err, ErrCredentialVerificationFailed) {
+		t.Errorf("verifyingStore.Put() error = %v, want not %v", err, ErrCredentialVerificationFailed)
+	}
+}
+
+func TestRegistryVerifier(t *testing.T) {
+	// create a test registry
+	testUsername := "test_username"
+	testPassword := "test_password"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wantedAuthHeader := "Basic " + base64.StdEncoding.EncodeToString([]byte(testUsername+":"+testPassword))
+		authHeader := r.Header.Get("Authorization")
+		if authHeader != wantedAuthHeader {
+			w.Header().Set("Www-Authenticate", `Basic realm="Test Server"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer ts.Close()
+	uri, _ := url.Parse(ts.URL)
+	reg, err := remote.NewRegistry(uri.Host)
+	if err != nil {
+		t.Fatalf("cannot create test registry: %v", err)
+	}
+	reg.PlainHTTP = true
+	vs := NewVerifyingStore(&testStore{}, RegistryVerifier(reg))
+	ctx := context.Background()
+
+	cred := auth.Credential{Username: testUsername, Password: testPassword}
+	if err := vs.Put(ctx, uri.Host, cred); err != nil {
+		t.Error("verifyingStore.Put() error =", err)
+	}
+	cred.Password = "whatever"
+	if err := vs.Put(ctx, uri.Host, cred); !errors.Is(err, ErrCredentialVerificationFailed) {
+		t.Errorf("verifyingStore.Put() error = %v, wantErr %v", err, ErrCredentialVerificationFailed)
+	}
+}